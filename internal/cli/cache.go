@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/metwurcht/torrent-all-in-one/internal/tmdb"
+	"github.com/metwurcht/torrent-all-in-one/internal/torrent"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var pruneMaxSize string
+
+func init() {
+	cachePruneCmd.Flags().StringVar(&pruneMaxSize, "max-size", "", "Taille maximale du cache après nettoyage (défaut: cache.max_size)")
+
+	viper.SetDefault("cache.pieces", true)
+	viper.SetDefault("cache.max_size", "512MiB")
+
+	cacheCmd.AddCommand(cachePruneCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Gère le cache local",
+}
+
+var cachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Nettoie le cache des hashes de pièces",
+	Long: `Nettoie le cache des hashes de pièces:
+1. Supprime les entrées dont le fichier source a changé ou disparu
+2. Supprime les entrées les moins récemment utilisées au-delà de la taille maximale`,
+	Args: cobra.NoArgs,
+	RunE: runCachePrune,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Vide le cache des métadonnées TMDB",
+	Args:  cobra.NoArgs,
+	RunE:  runCacheClear,
+}
+
+func runCacheClear(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	dir, err := tmdbCacheDir()
+	if err != nil {
+		return err
+	}
+	removed, err := tmdb.ClearCache(dir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🧹 %d entrée(s) TMDB supprimée(s) (%s)\n", removed, dir)
+	return nil
+}
+
+func runCachePrune(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	maxSize, err := cacheMaxSize()
+	if err != nil {
+		return err
+	}
+	if pruneMaxSize != "" {
+		if maxSize, err = torrent.ParseSize(pruneMaxSize); err != nil {
+			return fmt.Errorf("--max-size: %w", err)
+		}
+	}
+
+	dir, err := pieceCacheDir()
+	if err != nil {
+		return err
+	}
+	cache, err := torrent.OpenPieceCache(dir, maxSize)
+	if err != nil {
+		return err
+	}
+
+	stats, err := cache.Prune(maxSize)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🧹 %d entrée(s) supprimée(s) dont %d obsolète(s), %s libérés\n", stats.Removed, stats.Stale, formatBytes(stats.Freed))
+	fmt.Printf("📦 Taille du cache: %s (%s)\n", formatBytes(stats.Remaining), dir)
+	return nil
+}
+
+// cacheDir retourne le sous-dossier name du dossier de cache (cache.dir ou dossier de cache
+// de l'utilisateur), commun au cache de pièces et au cache TMDB
+func cacheDir(name string) (string, error) {
+	dir := viper.GetString("cache.dir")
+	if dir == "" {
+		userDir, err := os.UserCacheDir()
+		if err != nil {
+			return "", fmt.Errorf("dossier de cache introuvable: %w", err)
+		}
+		dir = filepath.Join(userDir, "torrent-aio")
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// pieceCacheDir retourne le dossier du cache de pièces (<cache.dir>/pieces)
+func pieceCacheDir() (string, error) {
+	return cacheDir("pieces")
+}
+
+// cacheMaxSize retourne la taille maximale configurée du cache (0 = pas de limite)
+func cacheMaxSize() (int64, error) {
+	value := viper.GetString("cache.max_size")
+	if value == "" {
+		return 0, nil
+	}
+	size, err := torrent.ParseSize(value)
+	if err != nil {
+		return 0, fmt.Errorf("cache.max_size: %w", err)
+	}
+	return size, nil
+}
+
+// openPieceCache ouvre le cache de pièces configuré (nil si désactivé)
+func openPieceCache() (*torrent.PieceCache, error) {
+	if !viper.GetBool("cache.pieces") {
+		return nil, nil
+	}
+
+	maxSize, err := cacheMaxSize()
+	if err != nil {
+		return nil, err
+	}
+	dir, err := pieceCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return torrent.OpenPieceCache(dir, maxSize)
+}
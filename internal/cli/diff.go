@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/metwurcht/torrent-all-in-one/internal/torrent"
+	"github.com/spf13/cobra"
+)
+
+var diffJSON bool
+
+func init() {
+	diffCmd.Flags().BoolVar(&diffJSON, "json", false, "Afficher le résultat au format JSON")
+
+	rootCmd.AddCommand(diffCmd)
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <a.torrent> <b.torrent>",
+	Short: "Compare deux fichiers torrent",
+	Long: `Compare deux fichiers torrent, par exemple deux releases d'un même film
+ou un torrent et sa version pour un autre tracker:
+- différences de champs (private, source, trackers, nom, taille de pièce...)
+- fichiers ajoutés, supprimés, redimensionnés, modifiés ou renommés
+- plages de pièces identiques (même contenu haché avec la même taille de pièce)
+
+Les renommages et modifications à taille égale ne sont détectés que pour
+les torrents v2 ou hybrides (pieces root par fichier).`,
+	Args: cobra.ExactArgs(2),
+	RunE: runDiff,
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	d, err := torrent.Diff(args[0], args[1])
+	if err != nil {
+		return fmt.Errorf("erreur comparaison: %w", err)
+	}
+
+	if diffJSON {
+		data, err := json.MarshalIndent(d, "", "  ")
+		if err != nil {
+			return fmt.Errorf("erreur encodage JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "\tA\tB\n")
+	fmt.Fprintf(w, "Torrent:\t%s\t%s\n", d.A.Torrent, d.B.Torrent)
+	fmt.Fprintf(w, "Infohash:\t%s\t%s\n", diffInfoHash(d.A), diffInfoHash(d.B))
+	fmt.Fprintf(w, "Taille totale:\t%s\t%s\n", formatBytes(d.A.TotalSize), formatBytes(d.B.TotalSize))
+	fmt.Fprintf(w, "Pièces:\t%d x %s\t%d x %s\n", d.A.Pieces, formatBytes(d.A.PieceLength), d.B.Pieces, formatBytes(d.B.PieceLength))
+	fmt.Fprintf(w, "Fichiers:\t%d\t%d\n", d.A.Files, d.B.Files)
+	w.Flush()
+
+	if d.SizeDelta != 0 {
+		sign := "+"
+		if d.SizeDelta < 0 {
+			sign = "-"
+		}
+		fmt.Printf("\nÉcart de taille: %s%s (%d octets)\n", sign, formatBytes(abs64(d.SizeDelta)), d.SizeDelta)
+	}
+
+	if len(d.Fields) > 0 {
+		fmt.Printf("\n📝 Champs différents (%d):\n", len(d.Fields))
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, f := range d.Fields {
+			fmt.Fprintf(w, "  %s:\t%s\t→ %s\n", f.Field, diffValue(f.A), diffValue(f.B))
+		}
+		w.Flush()
+	}
+
+	// Même dictionnaire info: seuls les champs hors info (trackers...) peuvent différer
+	if d.Identical {
+		fmt.Println("\n✅ Contenu identique (même infohash)")
+		return nil
+	}
+
+	fmt.Printf("\n📂 Fichiers: %d identique(s), %d différence(s)\n", d.IdenticalFiles, len(d.Files))
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, f := range d.Files {
+		switch f.Status {
+		case torrent.FileAdded:
+			fmt.Fprintf(w, "  + %s\t%s\n", f.Path, formatBytes(f.LengthB))
+		case torrent.FileRemoved:
+			fmt.Fprintf(w, "  - %s\t%s\n", f.Path, formatBytes(f.LengthA))
+		case torrent.FileResized:
+			fmt.Fprintf(w, "  ~ %s\t%s → %s\n", f.Path, formatBytes(f.LengthA), formatBytes(f.LengthB))
+		case torrent.FileModified:
+			fmt.Fprintf(w, "  ~ %s\t%s, contenu différent\n", f.Path, formatBytes(f.LengthA))
+		case torrent.FileRenamed:
+			fmt.Fprintf(w, "  > %s → %s\t%s\n", f.Path, f.NewPath, formatBytes(f.LengthA))
+		}
+	}
+	w.Flush()
+
+	if d.PieceHash != "" {
+		fmt.Printf("\n🧩 Pièces identiques (%s): %d/%d\n", d.PieceHash, d.IdenticalPieces, d.A.Pieces)
+		for _, r := range d.PieceRanges {
+			line := fmt.Sprintf("  A[%d-%d] = B[%d-%d]", r.StartA, r.StartA+r.Count-1, r.StartB, r.StartB+r.Count-1)
+			if len(r.Files) > 0 {
+				line += " (" + strings.Join(r.Files, ", ") + ")"
+			}
+			fmt.Println(line)
+		}
+	}
+
+	for _, note := range d.Notes {
+		fmt.Printf("\nℹ️  %s\n", note)
+	}
+	return nil
+}
+
+// diffInfoHash retourne l'infohash v1 du torrent, ou v2 pour un torrent v2 uniquement
+func diffInfoHash(side torrent.DiffSide) string {
+	if side.InfoHash.V1 != "" {
+		return side.InfoHash.V1
+	}
+	return side.InfoHash.V2
+}
+
+// diffValue affiche une valeur de champ vide de façon lisible
+func diffValue(value string) string {
+	if value == "" {
+		return "(vide)"
+	}
+	return value
+}
+
+// abs64 retourne la valeur absolue de n
+func abs64(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
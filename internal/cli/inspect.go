@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/metwurcht/torrent-all-in-one/internal/torrent"
+	"github.com/spf13/cobra"
+)
+
+var inspectJSON bool
+
+func init() {
+	inspectCmd.Flags().BoolVar(&inspectJSON, "json", false, "Afficher le résultat au format JSON")
+
+	rootCmd.AddCommand(inspectCmd)
+}
+
+var inspectCmd = &cobra.Command{
+	Use:   "inspect <fichier.torrent>",
+	Short: "Affiche les métadonnées d'un fichier torrent",
+	Long: `Affiche le contenu d'un fichier torrent pour audit:
+fichiers, tailles, taille et nombre de pièces, flag private, source,
+trackers, date de création et infohash(es).
+
+Les anomalies (aucun tracker, très peu de pièces, pièces incohérentes...)
+sont signalées à la fin.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runInspect,
+}
+
+func runInspect(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	report, err := torrent.Inspect(args[0])
+	if err != nil {
+		return fmt.Errorf("erreur inspection: %w", err)
+	}
+
+	if inspectJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("erreur encodage JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Nom:\t%s\n", report.Name)
+	fmt.Fprintf(w, "Format:\t%s\n", report.Version)
+	if report.InfoHash.V1 != "" {
+		fmt.Fprintf(w, "Infohash v1:\t%s\n", report.InfoHash.V1)
+	}
+	if report.InfoHash.V2 != "" {
+		fmt.Fprintf(w, "Infohash v2:\t%s\n", report.InfoHash.V2)
+	}
+	fmt.Fprintf(w, "Taille totale:\t%s (%d octets)\n", formatBytes(report.TotalSize), report.TotalSize)
+	fmt.Fprintf(w, "Pièces:\t%d x %s\n", report.Pieces, formatBytes(report.PieceLength))
+	fmt.Fprintf(w, "Privé:\t%t\n", report.Private)
+	if report.Source != "" {
+		fmt.Fprintf(w, "Source:\t%s\n", report.Source)
+	}
+	if report.Comment != "" {
+		fmt.Fprintf(w, "Commentaire:\t%s\n", report.Comment)
+	}
+	if report.CreatedBy != "" {
+		fmt.Fprintf(w, "Créé par:\t%s\n", report.CreatedBy)
+	}
+	if report.CreationDate != nil {
+		fmt.Fprintf(w, "Date de création:\t%s\n", report.CreationDate.Format("2006-01-02 15:04:05 MST"))
+	}
+	for i, tier := range report.Trackers {
+		fmt.Fprintf(w, "Tracker (tier %d):\t%s\n", i+1, strings.Join(tier, ", "))
+	}
+	for _, seed := range report.WebSeeds {
+		fmt.Fprintf(w, "Web seed:\t%s\n", seed)
+	}
+	w.Flush()
+
+	fmt.Printf("\n📂 Fichiers (%d):\n", len(report.Files))
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	for _, f := range report.Files {
+		path := f.Path
+		switch {
+		case f.Padding:
+			path += " (bourrage)"
+		case f.Attr != "":
+			path += " [" + f.Attr + "]"
+		}
+		fmt.Fprintf(w, "  %s\t  %s\t\n", formatBytes(f.Length), path)
+	}
+	w.Flush()
+
+	if len(report.Anomalies) == 0 {
+		fmt.Println("\n✅ Aucune anomalie détectée")
+		return nil
+	}
+
+	fmt.Printf("\n⚠️  Anomalies (%d):\n", len(report.Anomalies))
+	for _, anomaly := range report.Anomalies {
+		fmt.Printf("  - %s\n", anomaly)
+	}
+	return nil
+}
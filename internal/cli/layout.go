@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+
+	"github.com/metwurcht/torrent-all-in-one/internal/torrent"
+	"github.com/metwurcht/torrent-all-in-one/internal/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	layoutProfiles  []string
+	layoutOutputDir string
+	layoutVersion   string
+	layoutLinkDir   string
+	layoutLinkMode  string
+	layoutNoCache   bool
+)
+
+func init() {
+	layoutCreateCmd.Flags().StringSliceVarP(&layoutProfiles, "profile", "p", nil, "Profil(s) de tracker à utiliser (défaut: profiles de la config), un torrent par profil")
+	layoutCreateCmd.Flags().StringVarP(&layoutOutputDir, "output", "o", "", "Dossier de sortie (défaut: dossier du manifeste)")
+	layoutCreateCmd.Flags().StringVar(&layoutVersion, "torrent-version", "", "Format du torrent: v1, v2 ou hybrid (défaut: torrent_version de la config)")
+	layoutCreateCmd.Flags().StringVar(&layoutLinkDir, "link-dir", "", "Créer aussi l'arborescence de liens dans ce dossier")
+	layoutCreateCmd.Flags().BoolVar(&layoutNoCache, "no-cache", false, "Ne pas utiliser le cache des hashes de pièces")
+	for _, cmd := range []*cobra.Command{layoutCreateCmd, layoutLinkCmd} {
+		cmd.Flags().StringVar(&layoutLinkMode, "link-mode", "", "Type de lien: hardlink ou symlink (défaut: link_mode de la config, sinon hardlink)")
+	}
+
+	layoutCmd.AddCommand(layoutCreateCmd)
+	layoutCmd.AddCommand(layoutLinkCmd)
+	rootCmd.AddCommand(layoutCmd)
+}
+
+var layoutCmd = &cobra.Command{
+	Use:   "layout",
+	Short: "Torrents multi-fichiers construits à partir de fichiers dispersés",
+	Long: `Un manifeste de disposition (YAML ou JSON) associe chaque chemin du torrent
+à un fichier ou dossier quelconque sur disque:
+
+  name: Film.2024.1080p.WEB.H264-GROUPE
+  files:
+    - path: Film.2024.1080p.WEB.H264-GROUPE.mkv
+      source: /media/films/Film (2024)/film.mkv
+    - path: Film.2024.1080p.WEB.H264-GROUPE.nfo
+      source: Film.2024.1080p.WEB.H264-GROUPE.nfo
+
+Les sources relatives sont résolues depuis le dossier du manifeste.`,
+}
+
+var layoutCreateCmd = &cobra.Command{
+	Use:   "create <manifeste>",
+	Short: "Crée un torrent à partir d'un manifeste de disposition, sans copier les données",
+	Long: `Crée un torrent multi-fichiers à partir d'un manifeste de disposition:
+1. Lit chaque fichier à son emplacement d'origine (aucune copie)
+2. Écrit <nom>.torrent (ou un torrent par profil) et son lien magnet
+3. Avec --link-dir, reconstitue l'arborescence du torrent avec des liens pour le seed
+
+Trackers, taille de pièce, web seeds et mode reproductible suivent le fichier de configuration.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLayoutCreate,
+}
+
+var layoutLinkCmd = &cobra.Command{
+	Use:   "link <manifeste> <dossier>",
+	Short: "Reconstitue l'arborescence d'un manifeste avec des liens",
+	Long: `Crée <dossier>/<nom>/ avec un lien (physique par défaut, ou symbolique) vers chaque
+fichier du manifeste, pour qu'un client BitTorrent puisse seeder le torrent.
+Les liens déjà en place sont conservés; un fichier différent n'est jamais écrasé.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runLayoutLink,
+}
+
+func runLayoutCreate(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	layout, err := torrent.LoadLayout(args[0])
+	if err != nil {
+		return err
+	}
+
+	names := layoutProfiles
+	if !cmd.Flags().Changed("profile") {
+		names = viper.GetStringSlice("profiles")
+	}
+	trackerProfiles, err := loadProfiles(names)
+	if err != nil {
+		return err
+	}
+	announceList, err := announceTiers(cmd)
+	if err != nil {
+		return err
+	}
+
+	version := layoutVersion
+	if version == "" {
+		version = viper.GetString("torrent_version")
+	}
+	release := releaseTorrents{
+		name:         layout.Name,
+		outDir:       layoutOutputDir,
+		webSeeds:     viper.GetStringSlice("web_seeds"),
+		seedData:     torrent.WebSeedData{Name: layout.Name},
+		announceList: announceList,
+		profiles:     trackerProfiles,
+		watchTargets: viper.GetStringSlice("watch_dirs"),
+		noCache:      layoutNoCache,
+	}
+	if release.outDir == "" {
+		release.outDir = filepath.Dir(args[0])
+	}
+	if release.version, err = torrent.ParseVersion(version); err != nil {
+		return err
+	}
+	if release.pieces, err = loadPieceSizeConfig(); err != nil {
+		return err
+	}
+	if release.metadata, err = loadMetadataConfig(); err != nil {
+		return err
+	}
+
+	mode, err := layoutMode(cmd)
+	if err != nil {
+		return err
+	}
+
+	// L'arborescence doit exister avant l'export: un client qui surveille le dossier
+	// commence à vérifier les données dès l'arrivée du torrent
+	if layoutLinkDir != "" {
+		if err := linkLayout(layout, layoutLinkDir, mode); err != nil {
+			return err
+		}
+	}
+
+	_, err = release.create(ctx, ui.NewInteractivePrompter(), torrentSource{layout: layout})
+	return err
+}
+
+func runLayoutLink(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	layout, err := torrent.LoadLayout(args[0])
+	if err != nil {
+		return err
+	}
+	mode, err := layoutMode(cmd)
+	if err != nil {
+		return err
+	}
+	return linkLayout(layout, args[1], mode)
+}
+
+// layoutMode retourne le type de lien demandé (flag > env > config)
+func layoutMode(cmd *cobra.Command) (torrent.LinkMode, error) {
+	mode := layoutLinkMode
+	if !cmd.Flags().Changed("link-mode") {
+		mode = viper.GetString("link_mode")
+	}
+	return torrent.ParseLinkMode(mode)
+}
+
+// linkLayout reconstitue l'arborescence du manifeste dans dir
+func linkLayout(layout *torrent.Layout, dir string, mode torrent.LinkMode) error {
+	root, err := layout.Link(dir, mode)
+	if err != nil {
+		return fmt.Errorf("erreur arborescence de seed: %w", err)
+	}
+	fmt.Printf("🔗 Arborescence de seed (%s): %s\n", mode, root)
+	return nil
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/metwurcht/torrent-all-in-one/internal/torrent"
+	"github.com/spf13/viper"
+)
+
+// pieceSizeConfig regroupe les réglages de taille de pièce (flags > env > config)
+type pieceSizeConfig struct {
+	strategy torrent.PieceStrategy
+	size     int64
+	count    int
+	minSize  int64
+	maxSize  int64
+}
+
+// loadPieceSizeConfig lit et valide les réglages de taille de pièce
+func loadPieceSizeConfig() (pieceSizeConfig, error) {
+	var cfg pieceSizeConfig
+	var err error
+
+	// Une taille fixe sans stratégie explicite implique la stratégie fixed
+	strategy := viper.GetString("piece_strategy")
+	if strategy == "" && viper.GetString("piece_size") != "" {
+		strategy = string(torrent.PieceFixed)
+	}
+	if cfg.strategy, err = torrent.ParsePieceStrategy(strategy); err != nil {
+		return cfg, err
+	}
+
+	sizes := []struct {
+		key  string
+		dest *int64
+	}{
+		{"piece_size", &cfg.size},
+		{"min_piece_size", &cfg.minSize},
+		{"max_piece_size", &cfg.maxSize},
+	}
+	for _, s := range sizes {
+		if v := viper.GetString(s.key); v != "" {
+			if *s.dest, err = torrent.ParseSize(v); err != nil {
+				return cfg, fmt.Errorf("%s: %w", s.key, err)
+			}
+		}
+	}
+
+	if cfg.strategy == torrent.PieceFixed && cfg.size == 0 {
+		return cfg, fmt.Errorf("la stratégie fixed nécessite une taille de pièce (--piece-size)")
+	}
+
+	cfg.count = viper.GetInt("piece_count")
+	if cfg.strategy == torrent.PieceCount && cfg.count <= 0 {
+		return cfg, fmt.Errorf("nombre de pièces visé invalide: %d", cfg.count)
+	}
+
+	return cfg, nil
+}
+
+// apply configure la taille des pièces du générateur
+func (c pieceSizeConfig) apply(g *torrent.Generator) {
+	g.SetPieceStrategy(c.strategy)
+	if c.strategy == torrent.PieceFixed {
+		g.SetPieceSize(c.size)
+	}
+	g.SetTargetPieces(c.count)
+	g.SetPieceSizeBounds(c.minSize, c.maxSize)
+}
+
+// reportPieces affiche la taille de pièce retenue pour un torrent généré
+func reportPieces(torrentPath string, strategy torrent.PieceStrategy) error {
+	report, err := torrent.Inspect(torrentPath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("📐 Pièces: %d x %s (stratégie %s)\n", report.Pieces, formatBytes(report.PieceLength), strategy)
+	return nil
+}
@@ -2,18 +2,22 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/metwurcht/torrent-all-in-one/internal/mediainfo"
 	"github.com/metwurcht/torrent-all-in-one/internal/nfo"
-	"github.com/metwurcht/torrent-all-in-one/internal/presenter"
 	"github.com/metwurcht/torrent-all-in-one/internal/renamer"
+	"github.com/metwurcht/torrent-all-in-one/internal/seeder"
 	"github.com/metwurcht/torrent-all-in-one/internal/tmdb"
 	"github.com/metwurcht/torrent-all-in-one/internal/torrent"
 	"github.com/metwurcht/torrent-all-in-one/internal/ui"
+	"github.com/metwurcht/torrent-all-in-one/internal/uploader"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -21,23 +25,83 @@ import (
 // Les variables globales sont juste des placeholders pour les flags CLI
 // Les valeurs réelles sont gérées par Viper
 var (
-	outputDir   string
-	groupName   string
-	skipTorrent bool
-	noRename    bool
+	outputDir      string
+	groupName      string
+	skipTorrent    bool
+	skipClient     bool
+	noCache        bool
+	refreshTMDB    bool
+	upload         bool
+	seedAfter      bool
+	uploadDryRun   bool
+	noRename       bool
+	trackers       []string
+	profiles       []string
+	torrentVersion string
+	titlePolicy    string
+	webSeeds       []string
+	watchDirs      []string
+	pieceStrategy  string
+	pieceSize      string
+	pieceCount     int
+	minPieceSize   string
+	maxPieceSize   string
+	reproducible   bool
+	creationDate   string
+	createdBy      string
+	linkDir        string
+	linkMode       string
 )
 
 func init() {
 	processCmd.Flags().StringVarP(&outputDir, "output", "o", "", "Dossier de sortie (défaut: même dossier que le fichier)")
 	processCmd.Flags().StringVarP(&groupName, "group", "g", "", "Nom du groupe de release")
 	processCmd.Flags().BoolVar(&skipTorrent, "skip-torrent", false, "Ne pas générer le fichier torrent")
+	processCmd.Flags().BoolVar(&skipClient, "skip-client", false, "Ne pas ajouter le torrent au client BitTorrent configuré")
+	processCmd.Flags().BoolVar(&noCache, "no-cache", false, "Ne pas utiliser le cache des hashes de pièces")
+	processCmd.Flags().BoolVar(&refreshTMDB, "refresh", false, "Ignorer le cache des métadonnées TMDB et le mettre à jour")
+	processCmd.Flags().BoolVar(&upload, "upload", false, "Publier la release sur les trackers des profils (API UNIT3D)")
+	processCmd.Flags().BoolVar(&uploadDryRun, "upload-dry-run", false, "Préparer l'upload sans rien envoyer (implique --upload)")
+	processCmd.Flags().BoolVar(&seedAfter, "seed", false, "Seeder la release avec le client intégré une fois le traitement terminé (section seed)")
 	processCmd.Flags().BoolVar(&noRename, "no-rename", false, "Ne pas renommer le fichier vidéo")
+	processCmd.Flags().StringVar(&titlePolicy, "title-policy", "original", "Titre du nom de release: original, english, localized (langue TMDB) ou original-if-latin")
+	processCmd.Flags().StringSliceVarP(&profiles, "profile", "p", nil, "Profil(s) de tracker à utiliser (section trackers de la config), un torrent par profil")
+	processCmd.Flags().StringVar(&torrentVersion, "torrent-version", "v1", "Format du torrent: v1, v2 ou hybrid (BEP 52)")
+	processCmd.Flags().StringArrayVar(&webSeeds, "web-seed", nil, "URL de web seed (BEP 19, répétable), modèle avec {{.Name}} pour le nom de release")
+	processCmd.Flags().StringVar(&pieceStrategy, "piece-strategy", "", "Choix de la taille de pièce: auto (défaut), fixed ou count")
+	processCmd.Flags().StringVar(&pieceSize, "piece-size", "", "Taille de pièce fixe (ex: 4MiB), implique --piece-strategy fixed")
+	processCmd.Flags().IntVar(&pieceCount, "piece-count", 2000, "Nombre de pièces visé par la stratégie count")
+	processCmd.Flags().StringVar(&minPieceSize, "min-piece-size", "", "Taille de pièce minimale (ex: 256KiB)")
+	processCmd.Flags().StringVar(&maxPieceSize, "max-piece-size", "", "Taille de pièce maximale (ex: 16MiB)")
+	processCmd.Flags().BoolVar(&reproducible, "reproducible", false, "Torrent reproductible: mêmes données et paramètres, même fichier (pas de date de création par défaut)")
+	processCmd.Flags().StringVar(&creationDate, "creation-date", "", "Date de création fixe (RFC 3339, AAAA-MM-JJ, timestamp Unix ou none)")
+	processCmd.Flags().StringVar(&createdBy, "created-by", "", "Champ created by du torrent (défaut: Torrent-AIO)")
+	processCmd.Flags().StringVar(&linkDir, "link-dir", "", "Torrent multi-fichiers (vidéo + NFO) lu sur place, avec une arborescence de liens créée dans ce dossier pour le seed")
+	processCmd.Flags().StringVar(&linkMode, "link-mode", "hardlink", "Type de lien de l'arborescence de seed: hardlink ou symlink")
+	processCmd.Flags().StringArrayVar(&watchDirs, "watch-dir", nil, "Dossier surveillé par un client où copier les torrents générés (répétable)")
+	processCmd.Flags().StringArrayVar(&trackers, "tracker", nil, "URL d'annonce du tracker (répétable, un tier par flag, URLs d'un même tier séparées par des virgules)")
 
 	// Bind les flags avec viper pour permettre la configuration via fichier
 	viper.BindPFlag("group_name", processCmd.Flags().Lookup("group"))
 	viper.BindPFlag("skip_torrent", processCmd.Flags().Lookup("skip-torrent"))
+	viper.BindPFlag("skip_client", processCmd.Flags().Lookup("skip-client"))
 	viper.BindPFlag("no_rename", processCmd.Flags().Lookup("no-rename"))
+	viper.BindPFlag("title_policy", processCmd.Flags().Lookup("title-policy"))
+	viper.BindPFlag("upload", processCmd.Flags().Lookup("upload"))
+	viper.BindPFlag("upload_dry_run", processCmd.Flags().Lookup("upload-dry-run"))
 	viper.BindPFlag("output", processCmd.Flags().Lookup("output"))
+	viper.BindPFlag("profiles", processCmd.Flags().Lookup("profile"))
+	viper.BindPFlag("torrent_version", processCmd.Flags().Lookup("torrent-version"))
+	viper.BindPFlag("reproducible", processCmd.Flags().Lookup("reproducible"))
+	viper.BindPFlag("creation_date", processCmd.Flags().Lookup("creation-date"))
+	viper.BindPFlag("created_by", processCmd.Flags().Lookup("created-by"))
+	viper.BindPFlag("link_dir", processCmd.Flags().Lookup("link-dir"))
+	viper.BindPFlag("link_mode", processCmd.Flags().Lookup("link-mode"))
+	viper.BindPFlag("piece_strategy", processCmd.Flags().Lookup("piece-strategy"))
+	viper.BindPFlag("piece_size", processCmd.Flags().Lookup("piece-size"))
+	viper.BindPFlag("piece_count", processCmd.Flags().Lookup("piece-count"))
+	viper.BindPFlag("min_piece_size", processCmd.Flags().Lookup("min-piece-size"))
+	viper.BindPFlag("max_piece_size", processCmd.Flags().Lookup("max-piece-size"))
 
 	// Définir les valeurs par défaut
 	viper.SetDefault("group_name", "TORRENT-AIO")
@@ -48,38 +112,131 @@ func init() {
 }
 
 var processCmd = &cobra.Command{
-	Use:   "process <fichier_video>",
-	Short: "Traite un fichier vidéo pour créer une release",
-	Long: `Traite un fichier vidéo en:
-1. Identifiant le film via TMDB
+	Use:   "process <fichier_video|dossier_saison>",
+	Short: "Traite un fichier vidéo ou un dossier de saison pour créer une release",
+	Long: `Traite un fichier vidéo (film ou épisode SxxEyy) ou un dossier de saison en:
+1. Identifiant le film ou la série via TMDB
 2. Analysant les métadonnées du fichier
 3. Renommant le fichier selon les conventions warez
 4. Générant un NFO et une présentation bbcode
-5. Créant un fichier torrent`,
+5. Créant un fichier torrent (avec --link-dir: torrent <release>/ vidéo + NFO,
+   vidéo laissée en place et arborescence de liens créée pour le seed)
+6. L'ajoutant au client BitTorrent configuré (section client)
+7. Le seedant avec le client intégré (--seed)`,
 	Args: cobra.ExactArgs(1),
 	RunE: runProcess,
 }
 
 func runProcess(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	// Ctrl+C annule proprement les traitements longs (hachage du torrent)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
 	inputFile := args[0]
 
+	// Valider les trackers avant de lancer le traitement
+	announceList, err := announceTiers(cmd)
+	if err != nil {
+		return err
+	}
+
+	trackerProfiles, err := loadProfiles(viper.GetStringSlice("profiles"))
+	if err != nil {
+		return err
+	}
+	if len(trackerProfiles) > 0 && cmd.Flags().Changed("tracker") {
+		return fmt.Errorf("--tracker et --profile ne peuvent pas être utilisés ensemble")
+	}
+
+	version, err := torrent.ParseVersion(viper.GetString("torrent_version"))
+	if err != nil {
+		return err
+	}
+
+	namingPolicy, err := renamer.ParseTitlePolicy(viper.GetString("title_policy"))
+	if err != nil {
+		return err
+	}
+
+	pieceConfig, err := loadPieceSizeConfig()
+	if err != nil {
+		return err
+	}
+
+	metadata, err := loadMetadataConfig()
+	if err != nil {
+		return err
+	}
+
+	torrentClient, err := loadSeedClient()
+	if err != nil {
+		return err
+	}
+	watchTargets := watchDirTargets(cmd)
+
+	treeDir := viper.GetString("link_dir")
+	treeMode, err := torrent.ParseLinkMode(viper.GetString("link_mode"))
+	if err != nil {
+		return err
+	}
+
+	var seedOptions seeder.Options
+	if seedAfter {
+		if viper.GetBool("skip_torrent") {
+			return fmt.Errorf("--seed nécessite la génération du torrent (--skip-torrent incompatible)")
+		}
+		if seedOptions, err = loadSeedOptions(); err != nil {
+			return err
+		}
+	}
+
+	dryRun := viper.GetBool("upload_dry_run")
+	var uploaders []trackerUploader
+	if viper.GetBool("upload") || dryRun {
+		if viper.GetBool("skip_torrent") {
+			return fmt.Errorf("l'upload nécessite la génération du torrent (--skip-torrent incompatible)")
+		}
+		if uploaders, err = loadUploaders(trackerProfiles); err != nil {
+			return err
+		}
+	}
+
 	// Vérifier que le fichier existe
-	if _, err := os.Stat(inputFile); os.IsNotExist(err) {
+	info, err := os.Stat(inputFile)
+	if os.IsNotExist(err) {
 		return fmt.Errorf("fichier introuvable: %s", inputFile)
 	}
+	if err != nil {
+		return fmt.Errorf("erreur lecture fichier: %w", err)
+	}
 
 	absPath, err := filepath.Abs(inputFile)
 	if err != nil {
 		return fmt.Errorf("erreur chemin absolu: %w", err)
 	}
 
-	// Créer les services (plus besoin de clé API - on fait du scraping)
-	tmdbClient := tmdb.NewClient()
+	// Un épisode se reconnaît à son SxxEyy, un dossier est traité comme une saison complète
+	var episodeFiles []episodeFile
+	episodeRef, isEpisode := tmdb.ParseEpisode(filepath.Base(absPath))
+	isEpisode = isEpisode && !episodeRef.IsSeasonPack()
+	mediaPath := absPath
+	if info.IsDir() {
+		if episodeFiles, episodeRef, err = seasonEpisodes(absPath); err != nil {
+			return err
+		}
+		isEpisode = true
+		mediaPath = episodeFiles[0].path
+	}
+
+	// Créer les services (scraping TMDB sans clé, ou API officielle selon la config, avec cache disque)
+	tmdbClient, err := loadTMDBProvider(refreshTMDB)
+	if err != nil {
+		return err
+	}
 	analyzer := mediainfo.NewAnalyzer()
 	prompter := ui.NewInteractivePrompter()
 
-	// Lancer l'analyse du fichier en parallèle
+	// Lancer l'analyse du fichier en parallèle (premier épisode pour une saison)
 	var mediaInfo *mediainfo.MediaInfo
 	var mediaErr error
 	var wg sync.WaitGroup
@@ -88,13 +245,23 @@ func runProcess(cmd *cobra.Command, args []string) error {
 	go func() {
 		defer wg.Done()
 		fmt.Println("🔍 Analyse du fichier en cours...")
-		mediaInfo, mediaErr = analyzer.Analyze(absPath)
+		mediaInfo, mediaErr = analyzer.Analyze(mediaPath)
 	}()
 
 	// Identification TMDB
-	fmt.Println("🎬 Identification du film...")
-	filename := filepath.Base(inputFile)
-	movie, err := identifyMovie(ctx, tmdbClient, prompter, filename)
+	var content *releaseContent
+	if isEpisode {
+		fmt.Println("📺 Identification de la série...")
+		content, err = identifyEpisodes(ctx, tmdbClient, prompter, episodeRef, episodeFiles)
+	} else {
+		fmt.Println("🎬 Identification du film...")
+		var movie *tmdb.Movie
+		movie, err = identifyMovie(ctx, tmdbClient, prompter, filepath.Base(inputFile))
+		content = &releaseContent{movie: movie}
+	}
+	if errors.Is(err, tmdb.ErrUnavailable) {
+		return fmt.Errorf("erreur identification (réessayez plus tard): %w", err)
+	}
 	if err != nil {
 		return fmt.Errorf("erreur identification: %w", err)
 	}
@@ -105,7 +272,7 @@ func runProcess(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("erreur analyse fichier: %w", mediaErr)
 	}
 
-	fmt.Println("✅ Film identifié:", movie.OriginalTitle)
+	fmt.Println("✅", content.describe())
 	fmt.Println("✅ Analyse terminée")
 
 	// Déterminer le dossier de sortie
@@ -121,38 +288,89 @@ func runProcess(cmd *cobra.Command, args []string) error {
 
 	var newName string
 	var newPath string
+	var sourceType string
+
+	// Fichiers vidéo de la release: nom dans le torrent et emplacement sur le disque
+	ext := filepath.Ext(absPath)
+	if content.isPack() {
+		ext = ""
+	}
+	var dataFiles []torrent.LayoutFile
 
 	if noRename {
 		// Utiliser le nom de fichier actuel sans renommer
-		newName = filepath.Base(absPath)
-		newName = newName[:len(newName)-len(filepath.Ext(absPath))] // Retirer l'extension
+		newName = strings.TrimSuffix(filepath.Base(absPath), ext) // Retirer l'extension
 		newPath = absPath
 		fmt.Printf("📝 Utilisation du nom actuel: %s\n", newName)
+
+		for _, f := range content.files {
+			dataFiles = append(dataFiles, f.layoutFiles(strings.TrimSuffix(filepath.Base(f.path), filepath.Ext(f.path)))...)
+		}
+
+		// Le type de source reste nécessaire pour choisir le type de l'upload
+		if len(uploaders) > 0 {
+			if sourceType, err = prompter.SelectSourceType(); err != nil {
+				return fmt.Errorf("erreur sélection source: %w", err)
+			}
+		}
 	} else {
 		// Demander le type de source à l'utilisateur
-		sourceType, err := prompter.SelectSourceType()
+		sourceType, err = prompter.SelectSourceType()
 		if err != nil {
 			return fmt.Errorf("erreur sélection source: %w", err)
 		}
 		// Générer un nouveau nom et renommer
 		ren := renamer.NewRenamer(group)
-		newName = ren.GenerateName(movie, mediaInfo, sourceType)
-		newPath = filepath.Join(outDir, newName+filepath.Ext(absPath))
+		ren.SetTitlePolicy(namingPolicy)
+		newName = content.name(ren, mediaInfo, sourceType)
 
-		fmt.Printf("📝 Renommage: %s\n", newName)
-		if err := os.Rename(absPath, newPath); err != nil {
-			return fmt.Errorf("erreur renommage: %w", err)
+		// Chaque épisode d'une saison est renommé individuellement, avec ses sous-titres
+		for _, f := range content.files {
+			episodeName := ren.GenerateEpisodeName(content.show, content.season, f.ref.Episodes, mediaInfo, sourceType)
+			dataFiles = append(dataFiles, f.layoutFiles(episodeName)...)
 		}
 
-		// Mettre à jour le chemin dans mediaInfo après le renommage
-		mediaInfo.FilePath = newPath
+		switch {
+		case treeDir != "":
+			// Le nouveau nom n'existe que dans le torrent et l'arborescence de seed
+			newPath = absPath
+			fmt.Printf("📝 Nom de release: %s (vidéo laissée en place)\n", newName)
+		case content.isPack():
+			// Les épisodes et leurs sous-titres sont déplacés dans un dossier au nom de la saison
+			newPath = filepath.Join(outDir, newName)
+			fmt.Printf("📝 Renommage: %s (%d épisodes)\n", newName, len(content.files))
+			if err := movePack(dataFiles, newPath); err != nil {
+				return err
+			}
+			if newPath != absPath {
+				warnLeftovers(absPath)
+			}
+			mediaInfo.FilePath = dataFiles[0].Source
+		default:
+			newPath = filepath.Join(outDir, newName+ext)
+
+			fmt.Printf("📝 Renommage: %s\n", newName)
+			if err := os.Rename(absPath, newPath); err != nil {
+				return fmt.Errorf("erreur renommage: %w", err)
+			}
+
+			// Mettre à jour le chemin dans mediaInfo après le renommage
+			mediaInfo.FilePath = newPath
+		}
+	}
+	if !content.isPack() {
+		dataFiles = []torrent.LayoutFile{{Path: newName + ext, Source: newPath}}
 	}
 
-	// Générer le NFO
+	// Générer le NFO (dans le dossier d'une saison renommée, pour qu'il fasse partie du torrent)
 	fmt.Println("📄 Génération du NFO...")
 	nfoGen := nfo.NewGenerator(group)
-	nfoContent := nfoGen.Generate(movie, mediaInfo, newName+filepath.Ext(absPath))
+	metadata.applyNFO(nfoGen)
+	nfoContent := content.nfo(nfoGen, mediaInfo, newName+ext)
 	nfoPath := filepath.Join(outDir, newName+".nfo")
+	if content.isPack() && !noRename && treeDir == "" {
+		nfoPath = filepath.Join(newPath, newName+".nfo")
+	}
 	if err := os.WriteFile(nfoPath, []byte(nfoContent), 0644); err != nil {
 		return fmt.Errorf("erreur écriture NFO: %w", err)
 	}
@@ -160,29 +378,149 @@ func runProcess(cmd *cobra.Command, args []string) error {
 
 	fmt.Println("📋 Génération de la présentation...")
 	// Générer la présentation BBCode
-	presentationContent := presenter.GenerateBBcode(movie, mediaInfo)
+	var totalSize int64
+	for _, f := range dataFiles {
+		if fi, err := os.Stat(f.Source); err == nil {
+			totalSize += fi.Size()
+		}
+	}
+	presentationContent := content.bbcode(mediaInfo, totalSize)
 	presentationPath := filepath.Join(outDir, newName+".bbcode")
 	if err := os.WriteFile(presentationPath, []byte(presentationContent), 0644); err != nil {
 		return fmt.Errorf("erreur écriture présentation: %w", err)
 	}
 	fmt.Printf("📋 Présentation créée: %s\n", presentationPath)
 
+	// Torrent multi-fichiers <release>/ avec les vidéos et le NFO, lus sur place
+	source := torrentSource{path: newPath}
+	dataDir, seedPath := filepath.Dir(newPath), newPath
+	if treeDir != "" {
+		absNFO, err := filepath.Abs(nfoPath)
+		if err != nil {
+			return fmt.Errorf("erreur chemin absolu: %w", err)
+		}
+		source.layout = &torrent.Layout{
+			Name:  newName,
+			Files: append(dataFiles, torrent.LayoutFile{Path: newName + ".nfo", Source: absNFO}),
+		}
+		manifestPath := filepath.Join(outDir, newName+".layout.yml")
+		if err := source.layout.Save(manifestPath); err != nil {
+			return err
+		}
+		fmt.Printf("🗂️  Manifeste créé: %s\n", manifestPath)
+
+		// Arborescence réelle pour le client de seed, sans copie des données
+		linkedPath, err := source.layout.Link(treeDir, treeMode)
+		if err != nil {
+			return fmt.Errorf("erreur arborescence de seed: %w", err)
+		}
+		fmt.Printf("🔗 Arborescence de seed (%s): %s\n", treeMode, linkedPath)
+		dataDir, seedPath = treeDir, linkedPath
+	}
+
+	// Le web seed d'un fichier unique pointe sur la vidéo, celui d'une saison sur le dossier
+	seedData := torrent.WebSeedData{Name: newName}
+	if !content.isPack() {
+		seedData.File = newName + ext
+	}
+
 	// Générer le torrent
 	if !skipTorrent {
-		fmt.Println("🧲 Génération du torrent...")
-		torrentGen := torrent.NewGenerator()
-		torrentPath := filepath.Join(outDir, newName+".torrent")
-		if err := torrentGen.Create(newPath, torrentPath); err != nil {
-			return fmt.Errorf("erreur génération torrent: %w", err)
+		release := releaseTorrents{
+			name:         newName,
+			outDir:       outDir,
+			version:      version,
+			pieces:       pieceConfig,
+			metadata:     metadata,
+			webSeeds:     webSeedTemplates(cmd),
+			seedData:     seedData,
+			announceList: announceList,
+			profiles:     trackerProfiles,
+			watchTargets: watchTargets,
+			noCache:      noCache,
+		}
+		torrentPaths, err := release.create(ctx, prompter, source)
+		if err != nil {
+			return err
+		}
+
+		if torrentClient != nil {
+			if err := torrentClient.push(ctx, torrentPaths, dataDir); err != nil {
+				return err
+			}
+		}
+
+		if len(uploaders) > 0 {
+			rawMediaInfo, err := analyzer.RawText(mediaInfo.FilePath)
+			if err != nil {
+				return fmt.Errorf("erreur mediainfo: %w", err)
+			}
+			release := uploader.Release{
+				Name:        newName,
+				Description: presentationContent,
+				MediaInfo:   rawMediaInfo,
+				SourceType:  sourceType,
+				Resolution:  mediaInfo.Video.Resolution,
+			}
+			content.fillUpload(&release)
+			if err := uploadRelease(ctx, uploaders, torrentPaths, release, dryRun); err != nil {
+				return err
+			}
+		}
+
+		if seedAfter {
+			if err := seedRelease(ctx, prompter, torrentPaths, seedPath, seedOptions); err != nil {
+				return err
+			}
 		}
-		fmt.Printf("✅ Torrent créé: %s\n", torrentPath)
 	}
 
 	fmt.Println("\n🎉 Traitement terminé avec succès!")
 	return nil
 }
 
-func identifyMovie(ctx context.Context, client *tmdb.Client, prompter ui.Prompter, filename string) (*tmdb.Movie, error) {
+// writeMagnet écrit le lien magnet d'un torrent dans un fichier .magnet voisin et l'affiche
+func writeMagnet(torrentPath string) error {
+	magnet, err := torrent.GetMagnet(torrentPath)
+	if err != nil {
+		return fmt.Errorf("erreur génération magnet: %w", err)
+	}
+
+	magnetPath := strings.TrimSuffix(torrentPath, ".torrent") + ".magnet"
+	if err := os.WriteFile(magnetPath, []byte(magnet+"\n"), 0644); err != nil {
+		return fmt.Errorf("erreur écriture magnet: %w", err)
+	}
+
+	fmt.Printf("🧲 Magnet: %s\n", magnet)
+	return nil
+}
+
+// webSeedTemplates retourne les modèles de web seed du run (flags > env > config)
+func webSeedTemplates(cmd *cobra.Command) []string {
+	if cmd.Flags().Changed("web-seed") {
+		return webSeeds
+	}
+	return viper.GetStringSlice("web_seeds")
+}
+
+// announceTiers retourne les tiers d'annonce (flags > env > config)
+func announceTiers(cmd *cobra.Command) ([][]string, error) {
+	var entries []string
+
+	if cmd.Flags().Changed("tracker") {
+		entries = trackers
+	} else {
+		entries = announceEntries(viper.Get("announce"))
+	}
+
+	tiers, err := torrent.ParseAnnounceTiers(entries)
+	if err != nil {
+		return nil, fmt.Errorf("erreur trackers: %w", err)
+	}
+	return tiers, nil
+}
+
+func identifyMovie(ctx context.Context, client tmdb.Provider, prompter ui.Prompter, filename string) (*tmdb.Movie, error) {
 	// Extraire les mots-clés du nom de fichier
 	keywords := tmdb.ExtractKeywords(filename)
 
@@ -212,7 +550,13 @@ func identifyMovie(ctx context.Context, client *tmdb.Client, prompter ui.Prompte
 
 		// Vérifier si c'est un ID direct
 		if id, ok := tmdb.ParseDirectID(input); ok {
-			return client.GetMovieDetails(ctx, id)
+			details, err := client.GetMovieDetails(ctx, id)
+			if errors.Is(err, tmdb.ErrNotFound) {
+				// Un ID erroné ne doit pas interrompre le traitement
+				fmt.Printf("ID %d introuvable sur TMDB.\n", id)
+				continue
+			}
+			return details, err
 		}
 
 		// Nouvelle recherche avec les termes fournis
@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/metwurcht/torrent-all-in-one/internal/torrent"
+	"github.com/spf13/viper"
+)
+
+// trackerConfig représente un profil de tracker dans la section trackers de la config
+type trackerConfig struct {
+	Announce     interface{} `mapstructure:"announce"`
+	Source       string      `mapstructure:"source"`
+	Comment      string      `mapstructure:"comment"`
+	Private      *bool       `mapstructure:"private"`
+	WebSeeds     []string    `mapstructure:"web_seeds"`
+	MinPieceSize string      `mapstructure:"min_piece_size"`
+	MaxPieceSize string      `mapstructure:"max_piece_size"`
+	Version      string      `mapstructure:"torrent_version"`
+}
+
+// loadProfiles charge les profils de tracker demandés depuis la configuration
+func loadProfiles(names []string) ([]torrent.Profile, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	var configs map[string]trackerConfig
+	if err := viper.UnmarshalKey("trackers", &configs); err != nil {
+		return nil, fmt.Errorf("erreur lecture des profils de tracker: %w", err)
+	}
+
+	var profiles []torrent.Profile
+	seen := make(map[string]bool)
+
+	for _, name := range names {
+		// Viper normalise les clés en minuscules
+		key := strings.ToLower(strings.TrimSpace(name))
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		cfg, ok := configs[key]
+		if !ok {
+			return nil, fmt.Errorf("profil de tracker inconnu: %s (disponibles: %s)", name, strings.Join(profileNames(configs), ", "))
+		}
+
+		tiers, err := torrent.ParseAnnounceTiers(announceEntries(cfg.Announce))
+		if err != nil {
+			return nil, fmt.Errorf("profil %s: %w", key, err)
+		}
+
+		// Les profils ciblent des trackers privés par défaut
+		private := true
+		if cfg.Private != nil {
+			private = *cfg.Private
+		}
+
+		profile := torrent.Profile{
+			Name:         key,
+			AnnounceList: tiers,
+			Source:       cfg.Source,
+			Comment:      cfg.Comment,
+			Private:      private,
+			WebSeeds:     cfg.WebSeeds,
+		}
+
+		// Limites de taille de pièce imposées par le tracker (ex: "16MiB")
+		if cfg.MinPieceSize != "" {
+			if profile.MinPieceSize, err = torrent.ParseSize(cfg.MinPieceSize); err != nil {
+				return nil, fmt.Errorf("profil %s: min_piece_size: %w", key, err)
+			}
+		}
+		if cfg.MaxPieceSize != "" {
+			if profile.MaxPieceSize, err = torrent.ParseSize(cfg.MaxPieceSize); err != nil {
+				return nil, fmt.Errorf("profil %s: max_piece_size: %w", key, err)
+			}
+		}
+
+		// Format imposé par le tracker (ex: v1 uniquement), sinon celui de la release
+		if cfg.Version != "" {
+			if profile.Version, err = torrent.ParseVersion(cfg.Version); err != nil {
+				return nil, fmt.Errorf("profil %s: torrent_version: %w", key, err)
+			}
+		}
+
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, nil
+}
+
+// announceEntries convertit une valeur de configuration (URL, liste d'URLs ou liste de tiers)
+// en entrées acceptées par torrent.ParseAnnounceTiers
+func announceEntries(v interface{}) []string {
+	var entries []string
+
+	switch v := v.(type) {
+	case string:
+		entries = strings.Fields(v)
+	case []string:
+		entries = v
+	case []interface{}:
+		for _, item := range v {
+			switch tier := item.(type) {
+			case []interface{}:
+				urls := make([]string, 0, len(tier))
+				for _, u := range tier {
+					urls = append(urls, fmt.Sprint(u))
+				}
+				entries = append(entries, strings.Join(urls, ","))
+			default:
+				entries = append(entries, fmt.Sprint(tier))
+			}
+		}
+	}
+
+	return entries
+}
+
+func profileNames(configs map[string]trackerConfig) []string {
+	names := make([]string, 0, len(configs))
+	for name := range configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
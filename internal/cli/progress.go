@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/metwurcht/torrent-all-in-one/internal/torrent"
+	"github.com/metwurcht/torrent-all-in-one/internal/ui"
+)
+
+// progressInterval limite la fréquence de rafraîchissement de la barre de progression
+const progressInterval = 200 * time.Millisecond
+
+// hashProgress adapte la progression du hachage à ui.Prompter (octets traités, débit et ETA)
+func hashProgress(prompter ui.Prompter) torrent.ProgressFunc {
+	start := time.Now()
+	var last time.Time
+
+	return func(hashed, total int64) {
+		if total <= 0 {
+			return
+		}
+
+		now := time.Now()
+		if hashed < total && now.Sub(last) < progressInterval {
+			return
+		}
+		last = now
+
+		message := fmt.Sprintf("%s / %s", formatBytes(hashed), formatBytes(total))
+
+		elapsed := now.Sub(start)
+		if elapsed > 0 && hashed > 0 {
+			rate := float64(hashed) / elapsed.Seconds()
+			message += fmt.Sprintf(" - %s/s", formatBytes(int64(rate)))
+			if hashed < total {
+				eta := time.Duration(float64(total-hashed) / rate * float64(time.Second))
+				message += fmt.Sprintf(" - ETA %s", eta.Round(time.Second))
+			} else {
+				message += fmt.Sprintf(" - %s", elapsed.Round(time.Second))
+			}
+		}
+
+		prompter.ShowProgress(int(hashed), int(total), message+"   ")
+	}
+}
+
+// formatBytes formate une taille en octets (unités binaires)
+func formatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/metwurcht/torrent-all-in-one/internal/torrent"
+	"github.com/metwurcht/torrent-all-in-one/internal/ui"
+	"github.com/spf13/viper"
+)
+
+// torrentSource désigne les données d'une release: un fichier ou dossier, ou un manifeste de disposition
+type torrentSource struct {
+	path   string
+	layout *torrent.Layout
+}
+
+// releaseTorrents décrit les torrents à générer pour une release
+type releaseTorrents struct {
+	name         string // nom de release: <name>.torrent ou <name>.<profil>.torrent
+	outDir       string
+	version      torrent.Version
+	pieces       pieceSizeConfig
+	metadata     *metadataConfig
+	webSeeds     []string // modèles de web seed, évalués avec seedData
+	seedData     torrent.WebSeedData
+	announceList [][]string
+	profiles     []torrent.Profile
+	watchTargets []string
+	noCache      bool
+}
+
+// create lit la source une seule fois et écrit un torrent (ou un par profil de tracker),
+// avec son lien magnet et sa copie dans les dossiers surveillés. Retourne les torrents écrits.
+func (r *releaseTorrents) create(ctx context.Context, prompter ui.Prompter, source torrentSource) ([]string, error) {
+	fmt.Println("🧲 Génération du torrent...")
+	gen := torrent.NewGenerator()
+	gen.SetVersion(r.version)
+	r.pieces.apply(gen)
+	r.metadata.apply(gen)
+	if r.metadata.reproducible {
+		fmt.Println("🔁 Mode reproductible: torrent identique pour les mêmes données et paramètres")
+	}
+
+	// Les web seeds sont des modèles évalués avec le nom de release
+	runSeeds, err := torrent.ExpandWebSeeds(r.webSeeds, r.seedData)
+	if err != nil {
+		return nil, fmt.Errorf("erreur web seeds: %w", err)
+	}
+	if err := gen.SetWebSeeds(runSeeds); err != nil {
+		return nil, fmt.Errorf("erreur web seeds: %w", err)
+	}
+	gen.SetWorkers(viper.GetInt("hash_workers"))
+	if !r.noCache {
+		cache, err := openPieceCache()
+		if err != nil {
+			return nil, err
+		}
+		gen.SetCache(cache)
+	}
+	gen.SetProgress(hashProgress(prompter))
+
+	if len(r.profiles) == 0 {
+		if err := gen.SetAnnounceList(r.announceList); err != nil {
+			return nil, fmt.Errorf("erreur trackers: %w", err)
+		}
+		torrentPath := filepath.Join(r.outDir, r.name+".torrent")
+		if source.layout != nil {
+			err = gen.CreateFromLayout(ctx, source.layout, torrentPath)
+		} else {
+			err = gen.Create(ctx, source.path, torrentPath)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("erreur génération torrent: %w", err)
+		}
+		fmt.Printf("✅ Torrent créé: %s\n", torrentPath)
+		if err := reportPieces(torrentPath, r.pieces.strategy); err != nil {
+			return nil, err
+		}
+		if err := writeMagnet(torrentPath); err != nil {
+			return nil, err
+		}
+		if err := exportToWatchDirs(r.watchTargets, torrentPath, ""); err != nil {
+			return nil, err
+		}
+		return []string{torrentPath}, nil
+	}
+
+	// Un torrent par profil, nommé <release>.<profil>.torrent
+	profiles := make([]torrent.Profile, len(r.profiles))
+	torrentPaths := make([]string, len(r.profiles))
+	for i, profile := range r.profiles {
+		torrentPaths[i] = filepath.Join(r.outDir, r.name+"."+profile.Name+".torrent")
+
+		profileData := r.seedData
+		profileData.Profile = profile.Name
+		profiles[i] = profile
+		profiles[i].WebSeeds, err = torrent.ExpandWebSeeds(profile.WebSeeds, profileData)
+		if err != nil {
+			return nil, fmt.Errorf("profil %s: erreur web seeds: %w", profile.Name, err)
+		}
+	}
+	if source.layout != nil {
+		err = gen.CreateLayoutForProfiles(ctx, source.layout, profiles, torrentPaths)
+	} else {
+		err = gen.CreateForProfiles(ctx, source.path, profiles, torrentPaths)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erreur génération torrent: %w", err)
+	}
+	for i, profile := range profiles {
+		fmt.Printf("✅ Torrent créé (%s): %s\n", profile.Name, torrentPaths[i])
+		// Chaque profil a sa propre taille de pièce et son propre format
+		if err := reportPieces(torrentPaths[i], r.pieces.strategy); err != nil {
+			return nil, err
+		}
+		if err := writeMagnet(torrentPaths[i]); err != nil {
+			return nil, err
+		}
+		if err := exportToWatchDirs(r.watchTargets, torrentPaths[i], profile.Name); err != nil {
+			return nil, err
+		}
+	}
+	return torrentPaths, nil
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/metwurcht/torrent-all-in-one/internal/nfo"
+	"github.com/metwurcht/torrent-all-in-one/internal/torrent"
+	"github.com/spf13/viper"
+)
+
+// metadataConfig regroupe les champs du torrent qui ne dépendent pas des données:
+// mode reproductible, date de création et champ created by
+type metadataConfig struct {
+	reproducible bool
+	createdBy    *string
+	creationDate *time.Time
+}
+
+// loadMetadataConfig lit et valide les clés reproducible, creation_date et created_by
+func loadMetadataConfig() (*metadataConfig, error) {
+	cfg := &metadataConfig{reproducible: viper.GetBool("reproducible")}
+
+	// Une valeur vide est légitime (champ omis): seule une clé absente garde la valeur par défaut
+	if viper.IsSet("created_by") {
+		createdBy := viper.GetString("created_by")
+		cfg.createdBy = &createdBy
+	}
+	if raw := viper.GetString("creation_date"); raw != "" {
+		date, err := torrent.ParseCreationDate(raw)
+		if err != nil {
+			return nil, fmt.Errorf("creation_date: %w", err)
+		}
+		cfg.creationDate = &date
+	}
+
+	return cfg, nil
+}
+
+// apply configure le générateur
+func (c *metadataConfig) apply(g *torrent.Generator) {
+	g.SetReproducible(c.reproducible)
+	if c.createdBy != nil {
+		g.SetCreatedBy(*c.createdBy)
+	}
+	if c.creationDate != nil {
+		g.SetCreationDate(*c.creationDate)
+	}
+}
+
+// applyNFO date le NFO comme le torrent: il fait partie des données du torrent d'une saison
+// ou d'un manifeste, et sa date ne doit pas changer l'infohash en mode reproductible
+func (c *metadataConfig) applyNFO(g *nfo.Generator) {
+	switch {
+	case c.creationDate != nil:
+		g.SetDate(*c.creationDate)
+	case c.reproducible:
+		g.SetDate(time.Time{})
+	}
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/metwurcht/torrent-all-in-one/internal/torrent"
+	"github.com/spf13/cobra"
+)
+
+var (
+	retrackProfiles  []string
+	retrackOutputDir string
+)
+
+func init() {
+	retrackCmd.Flags().StringSliceVarP(&retrackProfiles, "profile", "p", nil, "Profil(s) de tracker cible(s) (section trackers de la config), un torrent par profil")
+	retrackCmd.Flags().StringVarP(&retrackOutputDir, "output", "o", "", "Dossier de sortie (défaut: même dossier que le torrent d'origine)")
+	retrackCmd.MarkFlagRequired("profile")
+
+	rootCmd.AddCommand(retrackCmd)
+}
+
+var retrackCmd = &cobra.Command{
+	Use:   "retrack <fichier.torrent>",
+	Short: "Réécrit un torrent existant pour un autre tracker sans rehacher",
+	Long: `Réécrit un torrent existant pour un autre tracker:
+1. Réutilise les pièces du torrent d'origine (aucun rehachage)
+2. Remplace trackers, source, flag private et commentaire par ceux du profil
+3. Écrit <release>.<profil>.torrent et son lien magnet
+
+La commande refuse de continuer si la taille de pièce du torrent
+sort des limites (min_piece_size / max_piece_size) du profil, ou si
+son format n'est pas le torrent_version du profil.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRetrack,
+}
+
+func runRetrack(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	torrentPath := args[0]
+
+	trackerProfiles, err := loadProfiles(retrackProfiles)
+	if err != nil {
+		return err
+	}
+
+	seedData, err := torrent.GetWebSeedData(torrentPath)
+	if err != nil {
+		return err
+	}
+
+	metadata, err := loadMetadataConfig()
+	if err != nil {
+		return err
+	}
+
+	outDir := retrackOutputDir
+	if outDir == "" {
+		outDir = filepath.Dir(torrentPath)
+	}
+
+	// Tous les profils sont vérifiés avant d'écrire le premier torrent
+	outputPaths := make([]string, len(trackerProfiles))
+	for i := range trackerProfiles {
+		profile := &trackerProfiles[i]
+		profileData := seedData
+		profileData.Profile = profile.Name
+		profile.WebSeeds, err = torrent.ExpandWebSeeds(profile.WebSeeds, profileData)
+		if err != nil {
+			return fmt.Errorf("profil %s: erreur web seeds: %w", profile.Name, err)
+		}
+		outputPaths[i] = filepath.Join(outDir, seedData.Name+"."+profile.Name+".torrent")
+	}
+
+	gen := torrent.NewGenerator()
+	metadata.apply(gen)
+	if err := gen.RetrackForProfiles(torrentPath, trackerProfiles, outputPaths); err != nil {
+		return fmt.Errorf("erreur retrack: %w", err)
+	}
+
+	for i, profile := range trackerProfiles {
+		hashes, err := torrent.GetInfoHash(outputPaths[i])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Torrent créé (%s): %s\n", profile.Name, outputPaths[i])
+		fmt.Printf("🔑 Infohash: %s\n", hashes)
+		if err := writeMagnet(outputPaths[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/metwurcht/torrent-all-in-one/internal/seeder"
+	"github.com/metwurcht/torrent-all-in-one/internal/torrent"
+	"github.com/metwurcht/torrent-all-in-one/internal/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var seedPeers []string
+
+func init() {
+	seedCmd.Flags().Int("port", 42069, "Port d'écoute du client embarqué (0 = port libre)")
+	seedCmd.Flags().String("upload-rate", "", "Limite d'envoi par seconde (ex: 5MiB, vide = illimité)")
+	seedCmd.Flags().Float64("ratio", 0, "S'arrêter une fois ce ratio atteint (0 = pas de limite)")
+	seedCmd.Flags().Duration("seed-time", 0, "S'arrêter après cette durée (ex: 48h, 0 = pas de limite)")
+	seedCmd.Flags().Duration("status-interval", 10*time.Second, "Intervalle d'affichage du statut")
+	seedCmd.Flags().Bool("dht", false, "Activer le DHT (torrents publics uniquement)")
+	seedCmd.Flags().StringArrayVar(&seedPeers, "peer", nil, "Pair à contacter directement (ip:port), répétable")
+
+	viper.BindPFlag("seed.listen_port", seedCmd.Flags().Lookup("port"))
+	viper.BindPFlag("seed.upload_rate", seedCmd.Flags().Lookup("upload-rate"))
+	viper.BindPFlag("seed.ratio", seedCmd.Flags().Lookup("ratio"))
+	viper.BindPFlag("seed.time", seedCmd.Flags().Lookup("seed-time"))
+	viper.BindPFlag("seed.status_interval", seedCmd.Flags().Lookup("status-interval"))
+	viper.BindPFlag("seed.dht", seedCmd.Flags().Lookup("dht"))
+
+	rootCmd.AddCommand(seedCmd)
+}
+
+var seedCmd = &cobra.Command{
+	Use:   "seed <fichier.torrent> <chemin>",
+	Short: "Seede des données avec le client BitTorrent intégré",
+	Long: `Seede des données sans client BitTorrent séparé:
+1. Démarre un client BitTorrent embarqué
+2. Vérifie les données sur disque
+3. Partage les données jusqu'au ratio ou à la durée demandés (ou jusqu'à Ctrl+C)
+
+Le chemin peut désigner les données elles-mêmes ou le dossier qui les contient.
+Les limites se configurent aussi dans la section seed du fichier de configuration.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSeed,
+}
+
+func runSeed(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	opts, err := loadSeedOptions()
+	if err != nil {
+		return err
+	}
+	opts.Peers = seedPeers
+
+	return seedRelease(ctx, ui.NewInteractivePrompter(), []string{args[0]}, args[1], opts)
+}
+
+// loadSeedOptions lit la configuration du client embarqué (section seed)
+func loadSeedOptions() (seeder.Options, error) {
+	opts := seeder.Options{
+		ListenPort:     viper.GetInt("seed.listen_port"),
+		Ratio:          viper.GetFloat64("seed.ratio"),
+		Duration:       viper.GetDuration("seed.time"),
+		StatusInterval: viper.GetDuration("seed.status_interval"),
+		DHT:            viper.GetBool("seed.dht"),
+	}
+	if opts.Ratio < 0 {
+		return opts, fmt.Errorf("seed.ratio: valeur négative (%g)", opts.Ratio)
+	}
+
+	if rate := viper.GetString("seed.upload_rate"); rate != "" {
+		limit, err := torrent.ParseSize(rate)
+		if err != nil {
+			return opts, fmt.Errorf("seed.upload_rate: %w", err)
+		}
+		opts.UploadRate = limit
+	}
+
+	return opts, nil
+}
+
+// seedRelease seede les torrents avec le client embarqué en affichant la vérification puis le statut
+func seedRelease(ctx context.Context, prompter ui.Prompter, torrentPaths []string, dataPath string, opts seeder.Options) error {
+	fmt.Printf("🔎 Vérification des données: %s\n", dataPath)
+	opts.Progress = hashProgress(prompter)
+	opts.Status = func(s seeder.Status) {
+		uploaded, peers, ratio := seedTotals(s)
+		fmt.Printf("⬆️  %s envoyés - ratio %.2f - %s/s - %d pair(s) - %s\n",
+			formatBytes(uploaded), ratio, formatBytes(int64(s.UploadRate)), peers, s.Elapsed.Round(time.Second))
+	}
+
+	limits := "Ctrl+C pour arrêter"
+	if opts.Ratio > 0 {
+		limits = fmt.Sprintf("ratio %.2f, %s", opts.Ratio, limits)
+	}
+	if opts.Duration > 0 {
+		limits = fmt.Sprintf("durée %s, %s", opts.Duration, limits)
+	}
+
+	// Le démarrage du seed est annoncé une fois la vérification terminée
+	progress := opts.Progress
+	started := false
+	opts.Progress = func(verified, total int64) {
+		progress(verified, total)
+		if verified == total && !started {
+			started = true
+			fmt.Printf("\n🌱 Seed en cours (port %d, %s)\n", opts.ListenPort, limits)
+		}
+	}
+
+	summary, err := seeder.Seed(ctx, torrentPaths, dataPath, opts)
+	if err != nil {
+		return fmt.Errorf("erreur seed: %w", err)
+	}
+
+	uploaded, _, ratio := seedTotals(summary.Status)
+	fmt.Printf("🛑 Seed arrêté (%s) après %s: %s envoyés, ratio %.2f\n",
+		summary.Reason, summary.Elapsed.Round(time.Second), formatBytes(uploaded), ratio)
+	return nil
+}
+
+// seedTotals additionne les envois et les pairs de tous les torrents et retourne le plus petit ratio
+func seedTotals(s seeder.Status) (uploaded int64, peers int, ratio float64) {
+	for i, t := range s.Torrents {
+		uploaded += t.Uploaded
+		peers += t.Peers
+		if i == 0 || t.Ratio < ratio {
+			ratio = t.Ratio
+		}
+	}
+	return uploaded, peers, ratio
+}
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/metwurcht/torrent-all-in-one/internal/client"
+	"github.com/spf13/viper"
+)
+
+// clientSettings représente la section client de la configuration
+type clientSettings struct {
+	client.Config `mapstructure:",squash"`
+	Category      string `mapstructure:"category"`
+	SavePath      string `mapstructure:"save_path"`
+	Paused        bool   `mapstructure:"paused"`
+	SkipRecheck   bool   `mapstructure:"skip_recheck"`
+}
+
+// seedClient associe un client BitTorrent configuré à ses options d'ajout
+type seedClient struct {
+	client client.Client
+	opts   client.AddOptions
+}
+
+// loadSeedClient crée le client BitTorrent configuré (nil si aucun client ou --skip-client)
+func loadSeedClient() (*seedClient, error) {
+	if viper.GetBool("skip_client") || !viper.IsSet("client") {
+		return nil, nil
+	}
+
+	var cfg clientSettings
+	if err := viper.UnmarshalKey("client", &cfg); err != nil {
+		return nil, fmt.Errorf("erreur lecture configuration client: %w", err)
+	}
+	if cfg.Type == "" {
+		return nil, nil
+	}
+
+	c, err := client.New(cfg.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &seedClient{
+		client: c,
+		opts: client.AddOptions{
+			SavePath:    cfg.SavePath,
+			Category:    cfg.Category,
+			Paused:      cfg.Paused,
+			SkipRecheck: cfg.SkipRecheck,
+		},
+	}, nil
+}
+
+// push ajoute les torrents générés au client, les données étant dans dataDir
+// (sauf si save_path est configuré, par exemple pour un client dans un conteneur)
+func (s *seedClient) push(ctx context.Context, torrentPaths []string, dataDir string) error {
+	opts := s.opts
+	if opts.SavePath == "" {
+		opts.SavePath = dataDir
+	}
+
+	for _, torrentPath := range torrentPaths {
+		if err := s.client.AddTorrent(ctx, torrentPath, opts); err != nil {
+			return fmt.Errorf("erreur ajout au client %s: %w", s.client.Name(), err)
+		}
+		fmt.Printf("🌱 Ajouté à %s: %s\n", s.client.Name(), filepath.Base(torrentPath))
+	}
+	return nil
+}
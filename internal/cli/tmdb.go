@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/metwurcht/torrent-all-in-one/internal/tmdb"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	viper.SetDefault("tmdb.cache_ttl", "168h")
+}
+
+// loadTMDBProvider crée la source de métadonnées configurée (section tmdb, scraping par défaut),
+// derrière le cache disque sauf si tmdb.cache_ttl vaut 0. refresh ignore les entrées en cache.
+func loadTMDBProvider(refresh bool) (tmdb.Provider, error) {
+	var cfg tmdb.Config
+	if err := viper.UnmarshalKey("tmdb", &cfg); err != nil {
+		return nil, fmt.Errorf("erreur lecture configuration tmdb: %w", err)
+	}
+	provider, err := tmdb.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := viper.GetDuration("tmdb.cache_ttl")
+	if ttl <= 0 {
+		return provider, nil
+	}
+	dir, err := tmdbCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	language := cfg.Language
+	if language == "" {
+		language = tmdb.DefaultLanguage
+	}
+	cached, err := tmdb.NewCachedProvider(provider, dir, language, ttl)
+	if err != nil {
+		return nil, err
+	}
+	cached.SetRefresh(refresh)
+	return cached, nil
+}
+
+// tmdbCacheDir retourne le dossier du cache des métadonnées TMDB (<cache.dir>/tmdb)
+func tmdbCacheDir() (string, error) {
+	return cacheDir("tmdb")
+}
@@ -0,0 +1,304 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/metwurcht/torrent-all-in-one/internal/mediainfo"
+	"github.com/metwurcht/torrent-all-in-one/internal/nfo"
+	"github.com/metwurcht/torrent-all-in-one/internal/presenter"
+	"github.com/metwurcht/torrent-all-in-one/internal/renamer"
+	"github.com/metwurcht/torrent-all-in-one/internal/tmdb"
+	"github.com/metwurcht/torrent-all-in-one/internal/torrent"
+	"github.com/metwurcht/torrent-all-in-one/internal/ui"
+	"github.com/metwurcht/torrent-all-in-one/internal/uploader"
+)
+
+// subtitleExtensions sont les sous-titres qui accompagnent un épisode (même nom, langue éventuelle)
+var subtitleExtensions = map[string]bool{
+	".srt": true, ".ass": true, ".ssa": true, ".sub": true,
+	".idx": true, ".sup": true, ".vtt": true,
+}
+
+// episodeFile est un fichier vidéo d'un dossier de saison et les épisodes qu'il contient
+type episodeFile struct {
+	path      string
+	ref       tmdb.EpisodeRef
+	subtitles []string // sous-titres de l'épisode: <vidéo sans extension>[.langue].srt
+}
+
+// layoutFiles retourne la vidéo et les sous-titres de l'épisode, nommés name dans le torrent
+// (le suffixe des sous-titres, langue comprise, est conservé)
+func (f episodeFile) layoutFiles(name string) []torrent.LayoutFile {
+	stem := strings.TrimSuffix(filepath.Base(f.path), filepath.Ext(f.path))
+	files := []torrent.LayoutFile{{Path: name + filepath.Ext(f.path), Source: f.path}}
+	for _, sub := range f.subtitles {
+		files = append(files, torrent.LayoutFile{Path: name + strings.TrimPrefix(filepath.Base(sub), stem), Source: sub})
+	}
+	return files
+}
+
+// releaseContent décrit ce que contient une release identifiée sur TMDB:
+// un film, un épisode (ou plusieurs dans un même fichier) ou une saison complète
+type releaseContent struct {
+	movie    *tmdb.Movie
+	show     *tmdb.Show
+	season   int
+	episodes []tmdb.Episode
+	files    []episodeFile // fichiers d'un pack de saison, vide sinon
+}
+
+// isPack indique si la release est un dossier de saison
+func (c *releaseContent) isPack() bool {
+	return len(c.files) > 0
+}
+
+// describe retourne le titre identifié pour l'affichage
+func (c *releaseContent) describe() string {
+	if c.movie != nil {
+		return "Film identifié: " + c.movie.OriginalTitle
+	}
+	var numbers []int
+	if !c.isPack() {
+		for _, e := range c.episodes {
+			numbers = append(numbers, e.EpisodeNumber)
+		}
+	}
+	return fmt.Sprintf("Série identifiée: %s %s", c.show.Name, tmdb.EpisodeCode(c.season, numbers...))
+}
+
+// name génère le nom de release
+func (c *releaseContent) name(ren *renamer.Renamer, media *mediainfo.MediaInfo, sourceType string) string {
+	switch {
+	case c.movie != nil:
+		return ren.GenerateName(c.movie, media, sourceType)
+	case c.isPack():
+		return ren.GenerateSeasonName(c.show, c.season, media, sourceType)
+	default:
+		var numbers []int
+		for _, e := range c.episodes {
+			numbers = append(numbers, e.EpisodeNumber)
+		}
+		return ren.GenerateEpisodeName(c.show, c.season, numbers, media, sourceType)
+	}
+}
+
+// nfo génère le NFO de la release; fileName est le nom du fichier vidéo ou du dossier de saison
+func (c *releaseContent) nfo(gen *nfo.Generator, media *mediainfo.MediaInfo, fileName string) string {
+	if c.movie != nil {
+		return gen.Generate(c.movie, media, fileName)
+	}
+	return gen.GenerateShow(c.show, c.episodes, media, fileName)
+}
+
+// bbcode génère la présentation de la release
+func (c *releaseContent) bbcode(media *mediainfo.MediaInfo, totalSize int64) string {
+	if c.movie != nil {
+		return presenter.GenerateBBcode(c.movie, media)
+	}
+	return presenter.GenerateShowBBcode(c.show, c.episodes, media, max(len(c.files), 1), totalSize)
+}
+
+// fillUpload renseigne la catégorie et les identifiants de la release à publier
+func (c *releaseContent) fillUpload(release *uploader.Release) {
+	if c.movie != nil {
+		release.Category = uploader.CategoryMovie
+		release.TMDbID = c.movie.ID
+		release.IMDbID = c.movie.IMDbID
+		return
+	}
+	release.Category = uploader.CategoryTV
+	release.TMDbID = c.show.ID
+	release.IMDbID = c.show.IMDbID
+	release.Season = c.season
+	if !c.isPack() && len(c.episodes) > 0 {
+		release.Episode = c.episodes[0].EpisodeNumber
+	}
+}
+
+// seasonEpisodes liste les épisodes d'un dossier de saison, triés par numéro, avec leurs sous-titres.
+// Les autres fichiers (NFO, bonus) sont ignorés.
+func seasonEpisodes(dir string) ([]episodeFile, tmdb.EpisodeRef, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, tmdb.EpisodeRef{}, fmt.Errorf("erreur lecture dossier: %w", err)
+	}
+
+	var files []episodeFile
+	for _, entry := range entries {
+		if entry.IsDir() || !tmdb.IsVideoFile(entry.Name()) {
+			continue
+		}
+		ref, ok := tmdb.ParseEpisode(entry.Name())
+		if !ok || ref.IsSeasonPack() {
+			return nil, tmdb.EpisodeRef{}, fmt.Errorf("numéro d'épisode introuvable (SxxEyy): %s", entry.Name())
+		}
+		files = append(files, episodeFile{path: filepath.Join(dir, entry.Name()), ref: ref})
+	}
+	for i := range files {
+		stem := strings.TrimSuffix(filepath.Base(files[i].path), filepath.Ext(files[i].path)) + "."
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.HasPrefix(entry.Name(), stem) && subtitleExtensions[strings.ToLower(filepath.Ext(entry.Name()))] {
+				files[i].subtitles = append(files[i].subtitles, filepath.Join(dir, entry.Name()))
+			}
+		}
+	}
+	if len(files) == 0 {
+		return nil, tmdb.EpisodeRef{}, fmt.Errorf("aucun épisode trouvé dans %s", dir)
+	}
+
+	season := files[0].ref.Season
+	for _, f := range files[1:] {
+		if f.ref.Season != season {
+			return nil, tmdb.EpisodeRef{}, fmt.Errorf("plusieurs saisons dans %s (S%02d et S%02d)", dir, season, f.ref.Season)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ref.Episodes[0] < files[j].ref.Episodes[0]
+	})
+
+	// Le nom du dossier décrit mieux la série que celui d'un épisode
+	ref := tmdb.EpisodeRef{Keywords: files[0].ref.Keywords, Season: season}
+	if dirRef, ok := tmdb.ParseEpisode(filepath.Base(dir)); ok && dirRef.Keywords != "" {
+		ref.Keywords = dirRef.Keywords
+	}
+	return files, ref, nil
+}
+
+// movePack déplace les fichiers d'un pack de saison dans dir, sous leur nom dans le torrent, et
+// met à jour leur emplacement. Toutes les destinations sont vérifiées avant le premier déplacement;
+// en cas d'échec, les fichiers déjà déplacés sont remis en place pour ne pas laisser la saison à moitié renommée.
+func movePack(files []torrent.LayoutFile, dir string) error {
+	targets := make(map[string]bool, len(files))
+	for _, f := range files {
+		target := filepath.Join(dir, f.Path)
+		if targets[target] {
+			return fmt.Errorf("erreur renommage: %s en double dans la saison", f.Path)
+		}
+		targets[target] = true
+		if target == f.Source {
+			continue
+		}
+		if _, err := os.Lstat(target); err == nil {
+			return fmt.Errorf("erreur renommage: %s existe déjà", target)
+		} else if !os.IsNotExist(err) {
+			return fmt.Errorf("erreur renommage: %w", err)
+		}
+	}
+
+	_, statErr := os.Stat(dir)
+	created := os.IsNotExist(statErr)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("erreur création dossier: %w", err)
+	}
+
+	moved := make([]string, 0, len(files))
+	for _, f := range files {
+		target := filepath.Join(dir, f.Path)
+		if err := os.Rename(f.Source, target); err != nil {
+			for i := len(moved) - 1; i >= 0; i-- {
+				if rbErr := os.Rename(filepath.Join(dir, files[i].Path), moved[i]); rbErr != nil {
+					fmt.Printf("⚠️  Impossible de remettre %s en place: %v\n", moved[i], rbErr)
+				}
+			}
+			if created {
+				os.Remove(dir)
+			}
+			return fmt.Errorf("erreur renommage: %w", err)
+		}
+		moved = append(moved, f.Source)
+	}
+	for i := range files {
+		files[i].Source = filepath.Join(dir, files[i].Path)
+	}
+	return nil
+}
+
+// warnLeftovers signale les fichiers restés dans le dossier d'origine d'une saison (bonus, NFO...)
+func warnLeftovers(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) == 0 {
+		return
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	fmt.Printf("⚠️  Fichiers non inclus dans la release, laissés dans %s: %s\n", dir, strings.Join(names, ", "))
+}
+
+// identifyEpisodes identifie la série puis récupère les épisodes de la release dans la saison
+func identifyEpisodes(ctx context.Context, client tmdb.Provider, prompter ui.Prompter, ref tmdb.EpisodeRef, files []episodeFile) (*releaseContent, error) {
+	show, err := identifyShow(ctx, client, prompter, ref.Keywords)
+	if err != nil {
+		return nil, err
+	}
+
+	season, err := client.GetSeason(ctx, show.ID, ref.Season)
+	if err != nil {
+		return nil, err
+	}
+
+	numbers := ref.Episodes
+	if len(files) > 0 {
+		numbers = nil
+		for _, f := range files {
+			numbers = append(numbers, f.ref.Episodes...)
+		}
+	}
+
+	content := &releaseContent{show: show, season: ref.Season, files: files}
+	for _, n := range numbers {
+		episode := season.Episode(n)
+		if episode == nil {
+			// Épisode absent de TMDB (pas encore référencé): on garde au moins son numéro
+			fmt.Printf("⚠️  %s introuvable sur TMDB\n", tmdb.EpisodeCode(ref.Season, n))
+			episode = &tmdb.Episode{SeasonNumber: ref.Season, EpisodeNumber: n}
+		}
+		content.episodes = append(content.episodes, *episode)
+	}
+	return content, nil
+}
+
+func identifyShow(ctx context.Context, client tmdb.Provider, prompter ui.Prompter, keywords string) (*tmdb.Show, error) {
+	for {
+		// Rechercher sur TMDB
+		results, err := client.SearchTV(ctx, keywords)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(results) == 0 {
+			fmt.Println("Aucun résultat trouvé.")
+		} else {
+			choice, err := prompter.SelectShow(results)
+			if err == nil {
+				// Récupérer les détails complets de la série
+				return client.GetTVDetails(ctx, choice.ID)
+			}
+		}
+
+		// Demander une nouvelle recherche ou un ID direct
+		input, err := prompter.AskForInput("Entrez un nouveau terme de recherche ou un ID TMDB (ex: id:1396):")
+		if err != nil {
+			return nil, err
+		}
+
+		if id, ok := tmdb.ParseDirectID(input); ok {
+			details, err := client.GetTVDetails(ctx, id)
+			if errors.Is(err, tmdb.ErrNotFound) {
+				// Un ID erroné ne doit pas interrompre le traitement
+				fmt.Printf("ID %d introuvable sur TMDB.\n", id)
+				continue
+			}
+			return details, err
+		}
+
+		keywords = input
+	}
+}
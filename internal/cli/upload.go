@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/metwurcht/torrent-all-in-one/internal/torrent"
+	"github.com/metwurcht/torrent-all-in-one/internal/uploader"
+	"github.com/spf13/viper"
+)
+
+// trackerUploader associe un profil de tracker à son API d'upload
+type trackerUploader struct {
+	profile string
+	api     *uploader.UNIT3D
+}
+
+// loadUploaders crée un uploader par profil de tracker (section upload de chaque profil)
+func loadUploaders(profiles []torrent.Profile) ([]trackerUploader, error) {
+	if len(profiles) == 0 {
+		return nil, fmt.Errorf("l'upload nécessite au moins un profil de tracker (--profile)")
+	}
+
+	uploaders := make([]trackerUploader, 0, len(profiles))
+	for _, profile := range profiles {
+		key := "trackers." + profile.Name + ".upload"
+		if !viper.IsSet(key) {
+			return nil, fmt.Errorf("profil %s: aucune section upload configurée", profile.Name)
+		}
+
+		var cfg uploader.Config
+		if err := viper.UnmarshalKey(key, &cfg); err != nil {
+			return nil, fmt.Errorf("profil %s: erreur lecture configuration upload: %w", profile.Name, err)
+		}
+		api, err := uploader.NewUNIT3D(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("profil %s: %w", profile.Name, err)
+		}
+		uploaders = append(uploaders, trackerUploader{profile: profile.Name, api: api})
+	}
+
+	return uploaders, nil
+}
+
+// uploadRelease publie la release sur chaque tracker, avec le torrent propre au profil
+func uploadRelease(ctx context.Context, uploaders []trackerUploader, torrentPaths []string, release uploader.Release, dryRun bool) error {
+	for i, u := range uploaders {
+		release.TorrentPath = torrentPaths[i]
+
+		result, err := u.api.Upload(ctx, release, dryRun)
+		if err != nil {
+			return fmt.Errorf("erreur upload (%s): %w", u.profile, err)
+		}
+
+		if result.DryRun {
+			fmt.Printf("🧪 Upload simulé (%s): %s\n", u.profile, release.TorrentPath)
+			printUploadFields(result.Fields)
+			continue
+		}
+		fmt.Printf("🚀 Upload réussi (%s): %s\n", u.profile, result.Message)
+		if result.DownloadURL != "" {
+			fmt.Printf("   %s\n", result.DownloadURL)
+		}
+	}
+	return nil
+}
+
+// printUploadFields affiche les champs d'un upload simulé, les textes longs étant résumés
+func printUploadFields(fields map[string]string) {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := fields[name]
+		if len(value) > 80 {
+			value = fmt.Sprintf("(%d caractères)", len(value))
+		}
+		fmt.Printf("   %-16s %s\n", name, value)
+	}
+}
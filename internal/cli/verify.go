@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/metwurcht/torrent-all-in-one/internal/torrent"
+	"github.com/metwurcht/torrent-all-in-one/internal/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var verifyReportPath string
+
+func init() {
+	verifyCmd.Flags().StringVar(&verifyReportPath, "report", "", "Écrire le rapport de vérification au format JSON dans ce fichier")
+
+	rootCmd.AddCommand(verifyCmd)
+}
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify <fichier.torrent> <chemin>",
+	Short: "Vérifie que les données sur disque correspondent à un torrent",
+	Long: `Vérifie les données d'un torrent avant de le seeder:
+1. Charge le fichier torrent (v1, v2 ou hybride)
+2. Recalcule le hash de chaque pièce
+3. Signale les fichiers manquants, tronqués ou corrompus
+
+Le chemin peut désigner les données elles-mêmes ou le dossier qui les contient.
+La commande se termine en erreur si les données ne correspondent pas.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runVerify,
+}
+
+func runVerify(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	torrentPath, dataPath := args[0], args[1]
+	prompter := ui.NewInteractivePrompter()
+
+	fmt.Printf("🔎 Vérification de %s...\n", torrentPath)
+	report, err := torrent.Verify(ctx, torrentPath, dataPath, viper.GetInt("hash_workers"), hashProgress(prompter))
+	if err != nil {
+		return fmt.Errorf("erreur vérification: %w", err)
+	}
+
+	fmt.Printf("📂 Données: %s\n", report.DataPath)
+	for _, f := range report.Files {
+		switch f.State {
+		case torrent.FileMissing:
+			fmt.Printf("  ❌ manquant   %s\n", f.Path)
+		case torrent.FileShort:
+			fmt.Printf("  ❌ tronqué    %s (%s / %s)\n", f.Path, formatBytes(f.Size), formatBytes(f.Length))
+		case torrent.FileCorrupt:
+			if f.Size != f.Length {
+				fmt.Printf("  ❌ corrompu   %s (taille %s au lieu de %s)\n", f.Path, formatBytes(f.Size), formatBytes(f.Length))
+			} else {
+				fmt.Printf("  ❌ corrompu   %s (%d pièce(s) invalide(s))\n", f.Path, f.BadPieces)
+			}
+		}
+	}
+
+	if verifyReportPath != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("erreur encodage rapport: %w", err)
+		}
+		if err := os.WriteFile(verifyReportPath, data, 0644); err != nil {
+			return fmt.Errorf("erreur écriture rapport: %w", err)
+		}
+		fmt.Printf("📄 Rapport écrit: %s\n", verifyReportPath)
+	}
+
+	if !report.OK() {
+		return fmt.Errorf("données incomplètes ou corrompues: %.1f%% (%d/%d pièces valides)", report.Percent, report.ValidPieces, report.Pieces)
+	}
+
+	fmt.Printf("✅ Données complètes: %.1f%% (%d/%d pièces valides)\n", report.Percent, report.ValidPieces, report.Pieces)
+	return nil
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/metwurcht/torrent-all-in-one/internal/torrent"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// watchDirTargets retourne les dossiers surveillés par les clients (flags > env > config)
+func watchDirTargets(cmd *cobra.Command) []string {
+	if cmd.Flags().Changed("watch-dir") {
+		return watchDirs
+	}
+	return viper.GetStringSlice("watch_dirs")
+}
+
+// exportToWatchDirs copie un torrent généré dans chaque dossier surveillé,
+// dans un sous-dossier par profil si watch_profile_subdirs est activé
+func exportToWatchDirs(dirs []string, torrentPath, profile string) error {
+	for _, dir := range dirs {
+		if profile != "" && viper.GetBool("watch_profile_subdirs") {
+			dir = filepath.Join(dir, profile)
+		}
+
+		copied, err := torrent.ExportToWatchDir(torrentPath, dir)
+		if err != nil {
+			return fmt.Errorf("erreur export vers %s: %w", dir, err)
+		}
+		if copied {
+			fmt.Printf("📥 Exporté: %s\n", filepath.Join(dir, filepath.Base(torrentPath)))
+		} else {
+			fmt.Printf("⏭️  Déjà présent dans %s (même infohash)\n", dir)
+		}
+	}
+	return nil
+}
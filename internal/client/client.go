@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"strings"
+	"time"
+)
+
+// Client est un client BitTorrent auquel on peut confier un torrent à seeder
+type Client interface {
+	// Name retourne le nom du backend (qbittorrent, transmission, deluge)
+	Name() string
+	// AddTorrent ajoute un fichier .torrent au client
+	AddTorrent(ctx context.Context, torrentPath string, opts AddOptions) error
+}
+
+// AddOptions contient les options d'ajout d'un torrent
+type AddOptions struct {
+	SavePath    string // dossier contenant les données
+	Category    string // catégorie (qBittorrent) ou label (Transmission, Deluge)
+	Paused      bool   // ajouter le torrent en pause
+	SkipRecheck bool   // ne pas revérifier les données (non supporté par Transmission)
+}
+
+// Config décrit la connexion à un client BitTorrent (section client de la config)
+type Config struct {
+	Type     string        `mapstructure:"type"`
+	URL      string        `mapstructure:"url"`
+	Username string        `mapstructure:"username"`
+	Password string        `mapstructure:"password"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+}
+
+// New crée le client correspondant au type configuré
+func New(cfg Config) (Client, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("URL du client %s manquante", cfg.Type)
+	}
+
+	httpClient, err := newHTTPClient(cfg.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	baseURL := strings.TrimRight(cfg.URL, "/")
+
+	switch strings.ToLower(cfg.Type) {
+	case "qbittorrent", "qbit":
+		return &QBittorrent{baseURL: baseURL, username: cfg.Username, password: cfg.Password, httpClient: httpClient}, nil
+	case "transmission":
+		return &Transmission{rpcURL: transmissionRPCURL(baseURL), username: cfg.Username, password: cfg.Password, httpClient: httpClient}, nil
+	case "deluge":
+		return &Deluge{baseURL: baseURL, password: cfg.Password, httpClient: httpClient}, nil
+	default:
+		return nil, fmt.Errorf("type de client inconnu: %q (qbittorrent, transmission ou deluge)", cfg.Type)
+	}
+}
+
+// newHTTPClient crée un client HTTP qui conserve les cookies de session
+func newHTTPClient(timeout time.Duration) (*http.Client, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, fmt.Errorf("erreur création cookie jar: %w", err)
+	}
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	return &http.Client{Jar: jar, Timeout: timeout}, nil
+}
@@ -0,0 +1,164 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Deluge pilote Deluge via l'API JSON-RPC de sa WebUI
+type Deluge struct {
+	baseURL    string
+	password   string
+	httpClient *http.Client
+	requestID  int
+	ready      bool
+}
+
+// Name retourne le nom du backend
+func (d *Deluge) Name() string {
+	return "deluge"
+}
+
+type delugeRequest struct {
+	Method string `json:"method"`
+	Params []any  `json:"params"`
+	ID     int    `json:"id"`
+}
+
+type delugeResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Message string `json:"message"`
+		Code    int    `json:"code"`
+	} `json:"error"`
+}
+
+// AddTorrent envoie le fichier torrent au démon Deluge connecté à la WebUI
+func (d *Deluge) AddTorrent(ctx context.Context, torrentPath string, opts AddOptions) error {
+	if err := d.connect(ctx); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(torrentPath)
+	if err != nil {
+		return fmt.Errorf("erreur lecture torrent: %w", err)
+	}
+
+	options := map[string]any{
+		"add_paused": opts.Paused,
+		"seed_mode":  opts.SkipRecheck,
+	}
+	if opts.SavePath != "" {
+		options["download_location"] = opts.SavePath
+	}
+
+	var torrentID string
+	params := []any{filepath.Base(torrentPath), base64.StdEncoding.EncodeToString(data), options}
+	if err := d.call(ctx, "core.add_torrent_file", params, &torrentID); err != nil {
+		return err
+	}
+	if torrentID == "" {
+		return fmt.Errorf("Deluge a refusé le torrent (déjà présent ?)")
+	}
+
+	if opts.Category != "" {
+		// Le label doit exister (plugin Label): une erreur "already exists" est sans conséquence
+		label := strings.ToLower(opts.Category)
+		if err := d.call(ctx, "label.add", []any{label}, nil); err != nil && !strings.Contains(err.Error(), "already exists") {
+			return err
+		}
+		if err := d.call(ctx, "label.set_torrent", []any{torrentID, label}, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// connect ouvre une session WebUI et s'assure qu'elle est reliée à un démon
+func (d *Deluge) connect(ctx context.Context) error {
+	if d.ready {
+		return nil
+	}
+
+	var ok bool
+	if err := d.call(ctx, "auth.login", []any{d.password}, &ok); err != nil {
+		return fmt.Errorf("erreur connexion Deluge: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("erreur connexion Deluge: mot de passe refusé")
+	}
+
+	var connected bool
+	if err := d.call(ctx, "web.connected", []any{}, &connected); err != nil {
+		return err
+	}
+	if !connected {
+		// Chaque hôte est décrit par [id, adresse, port, ...]
+		var hosts [][]any
+		if err := d.call(ctx, "web.get_hosts", []any{}, &hosts); err != nil {
+			return err
+		}
+		if len(hosts) == 0 || len(hosts[0]) == 0 {
+			return fmt.Errorf("aucun démon Deluge configuré dans la WebUI")
+		}
+		if err := d.call(ctx, "web.connect", []any{hosts[0][0]}, nil); err != nil {
+			return err
+		}
+	}
+
+	d.ready = true
+	return nil
+}
+
+// call exécute une méthode JSON-RPC et décode son résultat dans result (si non nil)
+func (d *Deluge) call(ctx context.Context, method string, params []any, result any) error {
+	d.requestID++
+	body, err := json.Marshal(delugeRequest{Method: method, Params: params, ID: d.requestID})
+	if err != nil {
+		return fmt.Errorf("erreur encodage requête: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.baseURL+"/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("erreur requête Deluge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("erreur lecture réponse Deluge: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Deluge: %s", resp.Status)
+	}
+
+	var rpc delugeResponse
+	if err := json.Unmarshal(data, &rpc); err != nil {
+		return fmt.Errorf("erreur décodage réponse Deluge: %w", err)
+	}
+	if rpc.Error != nil {
+		return fmt.Errorf("Deluge %s: %s", method, rpc.Error.Message)
+	}
+	if result != nil && len(rpc.Result) > 0 {
+		if err := json.Unmarshal(rpc.Result, result); err != nil {
+			return fmt.Errorf("erreur décodage réponse Deluge %s: %w", method, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDelugeAddTorrent(t *testing.T) {
+	torrentPath, torrentData := writeTorrent(t)
+
+	var calls []string
+	var added []any
+	var label []any
+	connected := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req delugeRequest
+		// t.Fatal ne doit pas être appelé hors de la goroutine du test
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		calls = append(calls, req.Method)
+
+		if req.Method != "auth.login" {
+			if c, err := r.Cookie("_session_id"); err != nil || c.Value != "session" {
+				fmt.Fprintf(w, `{"result":null,"error":{"message":"Not authenticated","code":1},"id":%d}`, req.ID)
+				return
+			}
+		}
+
+		result := "null"
+		switch req.Method {
+		case "auth.login":
+			if req.Params[0] == "secret" {
+				http.SetCookie(w, &http.Cookie{Name: "_session_id", Value: "session"})
+				result = "true"
+			} else {
+				result = "false"
+			}
+		case "web.connected":
+			result = fmt.Sprint(connected)
+		case "web.get_hosts":
+			result = `[["host1","127.0.0.1",58846,"localclient"]]`
+		case "web.connect":
+			if req.Params[0] != "host1" {
+				t.Errorf("web.connect %v", req.Params)
+			}
+			connected = true
+		case "core.add_torrent_file":
+			added = req.Params
+			result = `"0123456789abcdef0123456789abcdef01234567"`
+		case "label.add":
+			fmt.Fprintf(w, `{"result":null,"error":{"message":"Label already exists","code":4},"id":%d}`, req.ID)
+			return
+		case "label.set_torrent":
+			label = req.Params
+		}
+		fmt.Fprintf(w, `{"result":%s,"error":null,"id":%d}`, result, req.ID)
+	}))
+	defer server.Close()
+
+	c, err := New(Config{Type: "deluge", URL: server.URL, Password: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := AddOptions{SavePath: "/data/seed", Category: "Movies", SkipRecheck: true}
+	if err := c.AddTorrent(context.Background(), torrentPath, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"auth.login", "web.connected", "web.get_hosts", "web.connect", "core.add_torrent_file", "label.add", "label.set_torrent"}
+	if fmt.Sprint(calls) != fmt.Sprint(want) {
+		t.Errorf("appels = %v, want %v", calls, want)
+	}
+	if len(added) != 3 || added[0] != "release.torrent" || added[1] != base64.StdEncoding.EncodeToString(torrentData) {
+		t.Fatalf("core.add_torrent_file %v", added)
+	}
+	options := added[2].(map[string]any)
+	if options["download_location"] != "/data/seed" || options["seed_mode"] != true || options["add_paused"] != false {
+		t.Errorf("options = %v", options)
+	}
+	if fmt.Sprint(label) != "[0123456789abcdef0123456789abcdef01234567 movies]" {
+		t.Errorf("label.set_torrent %v", label)
+	}
+
+	bad, _ := New(Config{Type: "deluge", URL: server.URL, Password: "wrong"})
+	if err := bad.AddTorrent(context.Background(), torrentPath, opts); err == nil {
+		t.Error("un mot de passe refusé devrait produire une erreur")
+	}
+}
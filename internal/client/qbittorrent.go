@@ -0,0 +1,125 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// QBittorrent pilote qBittorrent via son API WebUI (v2)
+type QBittorrent struct {
+	baseURL    string
+	username   string
+	password   string
+	httpClient *http.Client
+	loggedIn   bool
+}
+
+// Name retourne le nom du backend
+func (q *QBittorrent) Name() string {
+	return "qbittorrent"
+}
+
+// AddTorrent envoie le fichier torrent à qBittorrent
+func (q *QBittorrent) AddTorrent(ctx context.Context, torrentPath string, opts AddOptions) error {
+	if err := q.login(ctx); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(torrentPath)
+	if err != nil {
+		return fmt.Errorf("erreur lecture torrent: %w", err)
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("torrents", filepath.Base(torrentPath))
+	if err != nil {
+		return fmt.Errorf("erreur construction requête: %w", err)
+	}
+	if _, err := part.Write(data); err != nil {
+		return fmt.Errorf("erreur construction requête: %w", err)
+	}
+
+	fields := map[string]string{
+		"savepath":      opts.SavePath,
+		"category":      opts.Category,
+		"paused":        strconv.FormatBool(opts.Paused),
+		"stopped":       strconv.FormatBool(opts.Paused), // qBittorrent >= 5.0
+		"skip_checking": strconv.FormatBool(opts.SkipRecheck),
+	}
+	for key, value := range fields {
+		if value == "" {
+			continue
+		}
+		if err := form.WriteField(key, value); err != nil {
+			return fmt.Errorf("erreur construction requête: %w", err)
+		}
+	}
+	if err := form.Close(); err != nil {
+		return fmt.Errorf("erreur construction requête: %w", err)
+	}
+
+	resp, err := q.post(ctx, "/api/v2/torrents/add", form.FormDataContentType(), &body)
+	if err != nil {
+		return err
+	}
+	if resp != "Ok." {
+		return fmt.Errorf("qBittorrent a refusé le torrent: %s", resp)
+	}
+	return nil
+}
+
+// login ouvre une session WebUI (cookie SID conservé par le client HTTP)
+func (q *QBittorrent) login(ctx context.Context) error {
+	if q.loggedIn {
+		return nil
+	}
+
+	form := url.Values{"username": {q.username}, "password": {q.password}}
+	resp, err := q.post(ctx, "/api/v2/auth/login", "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("erreur connexion qBittorrent: %w", err)
+	}
+	if resp != "Ok." {
+		return fmt.Errorf("erreur connexion qBittorrent: identifiants refusés")
+	}
+
+	q.loggedIn = true
+	return nil
+}
+
+// post envoie une requête à l'API et retourne le corps de la réponse
+func (q *QBittorrent) post(ctx context.Context, path, contentType string, body io.Reader) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, q.baseURL+path, body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", contentType)
+	// La protection CSRF de la WebUI exige un Referer du même hôte
+	req.Header.Set("Referer", q.baseURL)
+
+	resp, err := q.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("erreur requête qBittorrent: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("erreur lecture réponse qBittorrent: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("qBittorrent: %s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
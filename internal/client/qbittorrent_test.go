@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTorrent crée un faux fichier torrent pour les tests
+func writeTorrent(t *testing.T) (string, []byte) {
+	t.Helper()
+	data := []byte("d4:infod4:name4:testee")
+	path := filepath.Join(t.TempDir(), "release.torrent")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path, data
+}
+
+func TestQBittorrentAddTorrent(t *testing.T) {
+	torrentPath, torrentData := writeTorrent(t)
+
+	var fields map[string]string
+	var uploaded []byte
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/auth/login", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("username") != "admin" || r.FormValue("password") != "secret" {
+			io.WriteString(w, "Fails.")
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "SID", Value: "session", Path: "/"})
+		io.WriteString(w, "Ok.")
+	})
+	mux.HandleFunc("/api/v2/torrents/add", func(w http.ResponseWriter, r *http.Request) {
+		if c, err := r.Cookie("SID"); err != nil || c.Value != "session" {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		// t.Fatal ne doit pas être appelé hors de la goroutine du test
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Error(err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fields = map[string]string{}
+		for key, values := range r.MultipartForm.Value {
+			fields[key] = values[0]
+		}
+		file, _, err := r.FormFile("torrents")
+		if err != nil {
+			t.Error(err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		uploaded, _ = io.ReadAll(file)
+		io.WriteString(w, "Ok.")
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c, err := New(Config{Type: "qbittorrent", URL: server.URL, Username: "admin", Password: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := AddOptions{SavePath: "/data/seed", Category: "movies", Paused: true, SkipRecheck: true}
+	if err := c.AddTorrent(context.Background(), torrentPath, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	if string(uploaded) != string(torrentData) {
+		t.Errorf("torrent envoyé = %q", uploaded)
+	}
+	want := map[string]string{"savepath": "/data/seed", "category": "movies", "paused": "true", "stopped": "true", "skip_checking": "true"}
+	for key, value := range want {
+		if fields[key] != value {
+			t.Errorf("%s = %q, want %q", key, fields[key], value)
+		}
+	}
+
+	bad, _ := New(Config{Type: "qbittorrent", URL: server.URL, Username: "admin", Password: "wrong"})
+	if err := bad.AddTorrent(context.Background(), torrentPath, opts); err == nil {
+		t.Error("des identifiants refusés devraient produire une erreur")
+	}
+}
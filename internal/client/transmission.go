@@ -0,0 +1,129 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// transmissionSessionHeader est l'en-tête anti-CSRF de l'API RPC de Transmission
+const transmissionSessionHeader = "X-Transmission-Session-Id"
+
+// Transmission pilote Transmission via son API RPC
+type Transmission struct {
+	rpcURL     string
+	username   string
+	password   string
+	httpClient *http.Client
+	sessionID  string
+}
+
+// transmissionRPCURL complète l'URL de base avec le chemin RPC par défaut
+func transmissionRPCURL(baseURL string) string {
+	if u, err := url.Parse(baseURL); err == nil && (u.Path == "" || u.Path == "/") {
+		return baseURL + "/transmission/rpc"
+	}
+	return baseURL
+}
+
+// Name retourne le nom du backend
+func (t *Transmission) Name() string {
+	return "transmission"
+}
+
+type transmissionRequest struct {
+	Method    string         `json:"method"`
+	Arguments map[string]any `json:"arguments"`
+}
+
+type transmissionResponse struct {
+	Result    string          `json:"result"`
+	Arguments json.RawMessage `json:"arguments"`
+}
+
+// AddTorrent envoie le fichier torrent à Transmission.
+// Transmission ne permet pas de sauter la vérification: SkipRecheck est ignoré.
+func (t *Transmission) AddTorrent(ctx context.Context, torrentPath string, opts AddOptions) error {
+	data, err := os.ReadFile(torrentPath)
+	if err != nil {
+		return fmt.Errorf("erreur lecture torrent: %w", err)
+	}
+
+	args := map[string]any{
+		"metainfo": base64.StdEncoding.EncodeToString(data),
+		"paused":   opts.Paused,
+	}
+	if opts.SavePath != "" {
+		args["download-dir"] = opts.SavePath
+	}
+	if opts.Category != "" {
+		args["labels"] = []string{opts.Category}
+	}
+
+	resp, err := t.call(ctx, transmissionRequest{Method: "torrent-add", Arguments: args})
+	if err != nil {
+		return err
+	}
+	if resp.Result != "success" {
+		return fmt.Errorf("Transmission a refusé le torrent: %s", resp.Result)
+	}
+	return nil
+}
+
+// call exécute une requête RPC, en renouvelant l'identifiant de session si nécessaire
+func (t *Transmission) call(ctx context.Context, request transmissionRequest) (*transmissionResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("erreur encodage requête: %w", err)
+	}
+
+	// Le premier appel reçoit un 409 avec l'identifiant de session à renvoyer
+	for attempt := 0; attempt < 2; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.rpcURL, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if t.sessionID != "" {
+			req.Header.Set(transmissionSessionHeader, t.sessionID)
+		}
+		if t.username != "" || t.password != "" {
+			req.SetBasicAuth(t.username, t.password)
+		}
+
+		resp, err := t.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("erreur requête Transmission: %w", err)
+		}
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("erreur lecture réponse Transmission: %w", err)
+		}
+
+		switch resp.StatusCode {
+		case http.StatusConflict:
+			t.sessionID = resp.Header.Get(transmissionSessionHeader)
+			continue
+		case http.StatusUnauthorized:
+			return nil, fmt.Errorf("erreur connexion Transmission: identifiants refusés")
+		case http.StatusOK:
+		default:
+			return nil, fmt.Errorf("Transmission: %s", resp.Status)
+		}
+
+		var result transmissionResponse
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil, fmt.Errorf("erreur décodage réponse Transmission: %w", err)
+		}
+		return &result, nil
+	}
+
+	return nil, fmt.Errorf("Transmission: identifiant de session refusé")
+}
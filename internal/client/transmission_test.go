@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTransmissionAddTorrent(t *testing.T) {
+	torrentPath, torrentData := writeTorrent(t)
+
+	var request transmissionRequest
+	conflicts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/transmission/rpc" {
+			http.NotFound(w, r)
+			return
+		}
+		if user, pass, ok := r.BasicAuth(); !ok || user != "admin" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get(transmissionSessionHeader) != "abc" {
+			conflicts++
+			w.Header().Set(transmissionSessionHeader, "abc")
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		// t.Fatal ne doit pas être appelé hors de la goroutine du test
+		if err := json.Unmarshal(body, &request); err != nil {
+			t.Error(err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		io.WriteString(w, `{"result":"success","arguments":{"torrent-added":{"id":1}}}`)
+	}))
+	defer server.Close()
+
+	c, err := New(Config{Type: "transmission", URL: server.URL, Username: "admin", Password: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.AddTorrent(context.Background(), torrentPath, AddOptions{SavePath: "/data/seed", Category: "movies", Paused: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	if conflicts != 1 {
+		t.Errorf("%d réponses 409, want 1", conflicts)
+	}
+	if request.Method != "torrent-add" {
+		t.Errorf("method = %q", request.Method)
+	}
+	if request.Arguments["metainfo"] != base64.StdEncoding.EncodeToString(torrentData) {
+		t.Errorf("metainfo = %v", request.Arguments["metainfo"])
+	}
+	if request.Arguments["download-dir"] != "/data/seed" || request.Arguments["paused"] != true {
+		t.Errorf("arguments = %v", request.Arguments)
+	}
+	if labels, _ := request.Arguments["labels"].([]any); len(labels) != 1 || labels[0] != "movies" {
+		t.Errorf("labels = %v", request.Arguments["labels"])
+	}
+
+	bad, _ := New(Config{Type: "transmission", URL: server.URL, Username: "admin", Password: "wrong"})
+	if err := bad.AddTorrent(context.Background(), torrentPath, AddOptions{}); err == nil {
+		t.Error("des identifiants refusés devraient produire une erreur")
+	}
+}
@@ -45,6 +45,19 @@ func (a *Analyzer) Analyze(filePath string) (*MediaInfo, error) {
 	return mi, nil
 }
 
+// RawText retourne la sortie texte brute de mediainfo pour un fichier (format attendu par les trackers)
+func (a *Analyzer) RawText(filePath string) (string, error) {
+	cmd := exec.Command(a.mediaInfoPath, filePath)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("erreur exécution mediainfo: %w", err)
+	}
+
+	return strings.TrimSpace(out.String()), nil
+}
+
 func (a *Analyzer) analyzeWithMediaInfo(filePath string, mi *MediaInfo) error {
 	cmd := exec.Command(a.mediaInfoPath, "--Output=JSON", filePath)
 	var out bytes.Buffer
@@ -13,6 +13,7 @@ import (
 // Generator génère des fichiers NFO
 type Generator struct {
 	groupName string
+	date      *time.Time // date fixe du pied de page (zéro = omise), nil pour la date de génération
 }
 
 // NewGenerator crée un nouveau générateur NFO
@@ -22,6 +23,12 @@ func NewGenerator(groupName string) *Generator {
 	}
 }
 
+// SetDate fixe la date du pied de page; une date zéro l'omet, pour qu'un NFO inclus dans un
+// torrent reproductible ne change pas d'une génération à l'autre
+func (g *Generator) SetDate(date time.Time) {
+	g.date = &date
+}
+
 const nfoWidth = 120
 
 // Generate génère le contenu du fichier NFO en utilisant MediaInfo
@@ -45,7 +52,29 @@ func (g *Generator) Generate(movie *tmdb.Movie, media *mediainfo.MediaInfo, newF
 	sb.WriteString("\n")
 
 	// Footer avec informations supplémentaires du film
-	sb.WriteString(g.generateFooter(movie))
+	sb.WriteString(g.generateFooter())
+
+	return sb.String()
+}
+
+// GenerateShow génère le contenu du fichier NFO d'un épisode ou d'une saison d'une série.
+// media est l'analyse d'un fichier représentatif (le premier épisode pour une saison).
+func (g *Generator) GenerateShow(show *tmdb.Show, episodes []tmdb.Episode, media *mediainfo.MediaInfo, releaseName string) string {
+	var sb strings.Builder
+
+	sb.WriteString(g.generateShowHeader(show, episodes, releaseName))
+	sb.WriteString("\n")
+
+	mediaInfoOutput, err := g.getMediaInfoOutput(media.FilePath)
+	if err != nil {
+		sb.WriteString(fmt.Sprintf("Error getting MediaInfo output: %v\n", err))
+		sb.WriteString(fmt.Sprintf("File: %s\n", media.FileName))
+	} else {
+		sb.WriteString(mediaInfoOutput)
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(g.generateFooter())
 
 	return sb.String()
 }
@@ -147,14 +176,107 @@ func (g *Generator) generateHeader(movie *tmdb.Movie, newFileName string) string
 	return sb.String()
 }
 
-// generateFooter génère le pied de page du NFO avec le synopsis
-func (g *Generator) generateFooter(movie *tmdb.Movie) string {
+// generateShowHeader génère l'en-tête du NFO d'une série, avec la liste des épisodes
+func (g *Generator) generateShowHeader(show *tmdb.Show, episodes []tmdb.Episode, releaseName string) string {
+	border := strings.Repeat("=", nfoWidth)
+	thinBorder := strings.Repeat("-", nfoWidth)
+	var sb strings.Builder
+
+	sb.WriteString(border + "\n")
+	sb.WriteString(g.centerText(g.groupName+" presents", nfoWidth) + "\n")
+	sb.WriteString(border + "\n")
+	sb.WriteString(g.centerText(show.Name, nfoWidth) + "\n")
+	if show.OriginalName != "" && show.OriginalName != show.Name {
+		sb.WriteString(g.centerText(fmt.Sprintf("(%s)", show.OriginalName), nfoWidth) + "\n")
+	}
+	sb.WriteString(thinBorder + "\n")
+	sb.WriteString(fmt.Sprintf("Release Name: %s\n", releaseName))
+
+	if show.FirstAirDate != "" {
+		sb.WriteString(fmt.Sprintf("First Aired: %s\n", show.FirstAirDate))
+	}
+	if len(show.Networks) > 0 {
+		sb.WriteString(fmt.Sprintf("Network: %s\n", strings.Join(show.Networks, ", ")))
+	}
+	if len(show.Genres) > 0 {
+		sb.WriteString(fmt.Sprintf("Genre: %s\n", strings.Join(show.Genres, ", ")))
+	}
+	if show.EpisodeRuntime > 0 {
+		sb.WriteString(fmt.Sprintf("Runtime: %d min/episode\n", show.EpisodeRuntime))
+	}
+	if show.VoteAverage > 0 {
+		sb.WriteString(fmt.Sprintf("Rating: %.1f/10\n", show.VoteAverage))
+	}
+	if show.IMDbID != "" {
+		sb.WriteString(fmt.Sprintf("IMDb: %s\n", show.IMDbURL()))
+	}
+	sb.WriteString(fmt.Sprintf("TMDB: %s\n", show.TMDbURL()))
+
+	if len(show.Creators) > 0 {
+		sb.WriteString(fmt.Sprintf("Created by: %s\n", strings.Join(show.Creators, ", ")))
+	}
+
+	if len(show.Cast) > 0 {
+		actors := make([]string, 0, 5)
+		for i, c := range show.Cast {
+			if i >= 5 {
+				break
+			}
+			actors = append(actors, c.Name)
+		}
+		sb.WriteString(fmt.Sprintf("Cast: %s\n", strings.Join(actors, ", ")))
+	}
+
+	// Liste des épisodes: code, titre et date de diffusion
+	if len(episodes) > 0 {
+		sb.WriteString(border + "\n")
+		sb.WriteString(g.centerText("EPISODES", nfoWidth) + "\n")
+		sb.WriteString(thinBorder + "\n")
+		for _, e := range episodes {
+			line := fmt.Sprintf("%-8s %s", e.Code(), e.Name)
+			if e.AirDate != "" {
+				line += fmt.Sprintf(" (%s)", e.AirDate)
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+
+	sb.WriteString(border + "\n")
+	sb.WriteString(g.centerText("SYNOPSIS", nfoWidth) + "\n")
+	sb.WriteString(thinBorder + "\n\n")
+
+	// Un seul épisode: son résumé plutôt que celui de la série
+	overview := show.Overview
+	if len(episodes) == 1 && episodes[0].Overview != "" {
+		overview = episodes[0].Overview
+	}
+	if overview != "" {
+		sb.WriteString(g.wrapText(overview, nfoWidth))
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(border + "\n")
+
+	sb.WriteString(g.centerText("MEDIA INFORMATION", nfoWidth) + "\n")
+	sb.WriteString(thinBorder + "\n")
+
+	return sb.String()
+}
+
+// generateFooter génère le pied de page du NFO
+func (g *Generator) generateFooter() string {
 
 	border := strings.Repeat("=", nfoWidth)
 	var sb strings.Builder
 	sb.WriteString("\n" + border + "\n")
 	sb.WriteString(g.centerText("Generated by Torrent-AIO", nfoWidth) + "\n")
-	sb.WriteString(g.centerText(time.Now().Format("2006-01-02 15:04:05"), nfoWidth) + "\n")
+	date := time.Now()
+	if g.date != nil {
+		date = *g.date
+	}
+	if !date.IsZero() {
+		sb.WriteString(g.centerText(date.Format("2006-01-02 15:04:05"), nfoWidth) + "\n")
+	}
 	sb.WriteString(border + "\n")
 
 	return sb.String()
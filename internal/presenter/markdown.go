@@ -106,6 +106,20 @@ func GenerateBBcode(movie *tmdb.Movie, media *mediainfo.MediaInfo) string {
 	}
 
 	// Section Détails techniques
+	writeTechnicalDetails(&sb, media)
+
+	// Section Téléchargements
+	sb.WriteString("[font=Verdana][color=#9900ff][size=150][b]Téléchargements[/b][/size][/color][/font]\n \n")
+	sb.WriteString(fmt.Sprintf("[b]Fichier :[/b] %s\n", media.FileName))
+	sb.WriteString(fmt.Sprintf("[b]Poids Total :[/b] %s", media.FileSizeFormatted()))
+
+	sb.WriteString("[/center] \n")
+
+	return sb.String()
+}
+
+// writeTechnicalDetails écrit la section Détails techniques (format, codecs, pistes audio et sous-titres)
+func writeTechnicalDetails(sb *strings.Builder, media *mediainfo.MediaInfo) {
 	sb.WriteString("[font=Verdana][color=#9900ff][size=150][b]Détails techniques[/b][/size][/color][/font]\n \n[font=Verdana]")
 
 	// Format et codecs
@@ -158,15 +172,6 @@ func GenerateBBcode(movie *tmdb.Movie, media *mediainfo.MediaInfo) string {
 	}
 
 	sb.WriteString("[/font]\n \n")
-
-	// Section Téléchargements
-	sb.WriteString("[font=Verdana][color=#9900ff][size=150][b]Téléchargements[/b][/size][/color][/font]\n \n")
-	sb.WriteString(fmt.Sprintf("[b]Fichier :[/b] %s\n", media.FileName))
-	sb.WriteString(fmt.Sprintf("[b]Poids Total :[/b] %s", media.FileSizeFormatted()))
-
-	sb.WriteString("[/center] \n")
-
-	return sb.String()
 }
 
 // getCountryFlag retourne l'icône de drapeau pour une langue
@@ -0,0 +1,131 @@
+package presenter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/metwurcht/torrent-all-in-one/internal/mediainfo"
+	"github.com/metwurcht/torrent-all-in-one/internal/tmdb"
+)
+
+// GenerateShowBBcode génère une présentation BBCode d'un épisode ou d'une saison d'une série.
+// media est l'analyse d'un fichier représentatif; fileCount et totalSize décrivent l'ensemble de la release.
+func GenerateShowBBcode(show *tmdb.Show, episodes []tmdb.Episode, media *mediainfo.MediaInfo, fileCount int, totalSize int64) string {
+	var sb strings.Builder
+
+	sb.WriteString("[center]")
+
+	// Titre principal en rouge, suivi de la saison ou de l'épisode
+	sb.WriteString(fmt.Sprintf("[font=Verdana][size=200][color=#aa0000][b]%s[/b][/color][/size][/font]\n", show.Name))
+	if subtitle := episodesTitle(episodes); subtitle != "" {
+		sb.WriteString(fmt.Sprintf("[font=Verdana][size=150][color=#aa0000]%s[/color][/size][/font]\n", subtitle))
+	}
+	sb.WriteString("\n\n")
+
+	if show.PosterPath != "" {
+		sb.WriteString(fmt.Sprintf("[img]%s[/img]\n\n", show.PosterURL("w500")))
+	}
+
+	if show.Tagline != "" {
+		sb.WriteString(fmt.Sprintf("[font=Verdana][size=100][color=#aa0000][i]« %s »[/i][/color][/size][/font]\n", show.Tagline))
+		sb.WriteString(" \n \n")
+	}
+
+	// Section Informations
+	sb.WriteString("[font=Verdana][color=#9900ff][size=150][b]Informations[/b][/size][/color][/font]\n \n[font=Verdana]")
+
+	if show.OriginalName != "" && show.OriginalName != show.Name {
+		sb.WriteString(fmt.Sprintf("[b]Titre original :[/b] %s\n", show.OriginalName))
+	}
+	if show.FirstAirDate != "" {
+		sb.WriteString(fmt.Sprintf("[b]Première diffusion :[/b] %s\n", show.FirstAirDate))
+	}
+	if len(show.Networks) > 0 {
+		sb.WriteString(fmt.Sprintf("[b]Chaîne :[/b] %s\n", strings.Join(show.Networks, ", ")))
+	}
+	if show.EpisodeRuntime > 0 {
+		sb.WriteString(fmt.Sprintf("[b]Durée :[/b] %d min par épisode\n", show.EpisodeRuntime))
+	}
+	sb.WriteString(" \n")
+
+	if len(show.Creators) > 0 {
+		sb.WriteString(fmt.Sprintf("[b]Création :[/b] %s\n \n", strings.Join(show.Creators, ", ")))
+	}
+
+	// Acteurs (premiers 5)
+	if len(show.Cast) > 0 {
+		sb.WriteString("[b]Acteurs :[/b]\n")
+		for i, actor := range show.Cast {
+			if i >= 5 {
+				break
+			}
+			sb.WriteString(fmt.Sprintf("%s, ", actor.Name))
+		}
+		sb.WriteString("\n \n")
+	}
+
+	if len(show.Genres) > 0 {
+		sb.WriteString(fmt.Sprintf("[b]Genres :[/b]\n%s\n \n", strings.Join(show.Genres, ", ")))
+	}
+
+	if show.VoteAverage > 0 {
+		sb.WriteString(fmt.Sprintf("[img]https://zupimages.net/up/21/02/xro7.png[/img] %.2f\n \n", show.VoteAverage))
+	}
+
+	sb.WriteString(fmt.Sprintf("[img]https://zupimages.net/up/21/03/mxao.png[/img] [url=%s]Fiche de la série[/url]\n", show.TMDbURL()))
+	if show.IMDbID != "" {
+		sb.WriteString(fmt.Sprintf("[img]https://zupimages.net/up/21/03/od5a.png[/img] [url=%s]%s[/url]\n", show.IMDbURL(), show.IMDbID))
+	}
+
+	sb.WriteString("[/font]\n \n")
+
+	// Section Synopsis (celui de l'épisode s'il est seul)
+	sb.WriteString("[font=Verdana][color=#9900ff][size=150][b]Synopsis[/b][/size][/color][/font]\n \n[font=Verdana]\n")
+	overview := show.Overview
+	if len(episodes) == 1 && episodes[0].Overview != "" {
+		overview = episodes[0].Overview
+	}
+	sb.WriteString(overview)
+	sb.WriteString("\n \n \n[/font]\n")
+
+	// Section Épisodes
+	if len(episodes) > 0 {
+		sb.WriteString("[font=Verdana][color=#9900ff][size=150][b]Épisodes[/b][/size][/color][/font]\n \n[font=Verdana]")
+		for _, e := range episodes {
+			sb.WriteString(fmt.Sprintf("[b]%s[/b] %s", e.Code(), e.Name))
+			if e.AirDate != "" {
+				sb.WriteString(fmt.Sprintf(" (%s)", e.AirDate))
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("[/font]\n \n")
+	}
+
+	writeTechnicalDetails(&sb, media)
+
+	// Section Téléchargements
+	sb.WriteString("[font=Verdana][color=#9900ff][size=150][b]Téléchargements[/b][/size][/color][/font]\n \n")
+	if fileCount > 1 {
+		sb.WriteString(fmt.Sprintf("[b]Fichiers :[/b] %d épisodes\n", fileCount))
+	} else {
+		sb.WriteString(fmt.Sprintf("[b]Fichier :[/b] %s\n", media.FileName))
+	}
+	total := &mediainfo.MediaInfo{FileSize: totalSize}
+	sb.WriteString(fmt.Sprintf("[b]Poids Total :[/b] %s", total.FileSizeFormatted()))
+
+	sb.WriteString("[/center] \n")
+
+	return sb.String()
+}
+
+// episodesTitle retourne le sous-titre de la présentation: "Saison 1" ou "S01E02 - Titre"
+func episodesTitle(episodes []tmdb.Episode) string {
+	switch {
+	case len(episodes) == 0:
+		return ""
+	case len(episodes) == 1:
+		return fmt.Sprintf("%s - %s", episodes[0].Code(), episodes[0].Name)
+	default:
+		return fmt.Sprintf("Saison %d", episodes[0].SeasonNumber)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"unicode"
 
 	"github.com/metwurcht/torrent-all-in-one/internal/mediainfo"
 	"github.com/metwurcht/torrent-all-in-one/internal/tmdb"
@@ -11,23 +12,56 @@ import (
 
 // Renamer gère le renommage des fichiers selon les conventions warez
 type Renamer struct {
-	groupName string
+	groupName   string
+	titlePolicy TitlePolicy
+}
+
+// TitlePolicy choisit le titre TMDB utilisé dans les noms de release
+type TitlePolicy string
+
+const (
+	// TitleOriginal garde le titre original, quelle que soit son écriture
+	TitleOriginal TitlePolicy = "original"
+	// TitleEnglish prend le titre anglais (traduction ou titre alternatif US/GB)
+	TitleEnglish TitlePolicy = "english"
+	// TitleLocalized prend le titre dans la langue configurée pour TMDB (tmdb.language)
+	TitleLocalized TitlePolicy = "localized"
+	// TitleOriginalIfLatin garde le titre original s'il est en alphabet latin, sinon le titre anglais
+	TitleOriginalIfLatin TitlePolicy = "original-if-latin"
+)
+
+// ParseTitlePolicy convertit une politique de titre de la configuration (original par défaut)
+func ParseTitlePolicy(s string) (TitlePolicy, error) {
+	switch p := TitlePolicy(strings.ToLower(strings.TrimSpace(s))); p {
+	case "":
+		return TitleOriginal, nil
+	case TitleOriginal, TitleEnglish, TitleLocalized, TitleOriginalIfLatin:
+		return p, nil
+	default:
+		return "", fmt.Errorf("politique de titre invalide: %q (original, english, localized ou original-if-latin)", s)
+	}
 }
 
 // NewRenamer crée un nouveau renamer
 func NewRenamer(groupName string) *Renamer {
 	return &Renamer{
-		groupName: groupName,
+		groupName:   groupName,
+		titlePolicy: TitleOriginal,
 	}
 }
 
+// SetTitlePolicy définit le titre utilisé dans les noms de release
+func (r *Renamer) SetTitlePolicy(policy TitlePolicy) {
+	r.titlePolicy = policy
+}
+
 // GenerateName génère le nom de release selon les conventions warez
 // Format: Titre.Annee.Resolution.Source.VideoCodec.AudioCodec-GROUP
 func (r *Renamer) GenerateName(movie *tmdb.Movie, media *mediainfo.MediaInfo, sourceType string) string {
 	parts := []string{}
 
 	// Titre (remplacer les espaces par des points, nettoyer les caractères spéciaux)
-	title := r.cleanTitle(movie.OriginalTitle)
+	title := r.cleanTitle(r.chooseTitle(movie.OriginalTitle, movie.Title, movie.TitleIn("en")))
 	parts = append(parts, title)
 
 	// Année
@@ -35,6 +69,62 @@ func (r *Renamer) GenerateName(movie *tmdb.Movie, media *mediainfo.MediaInfo, so
 		parts = append(parts, year)
 	}
 
+	return r.releaseName(parts, media, sourceType)
+}
+
+// GenerateEpisodeName génère le nom de release d'un ou plusieurs épisodes d'une saison
+// Format: Serie.S01E02.Resolution.Source.VideoCodec.AudioCodec-GROUP (S01E02E03 pour un multi-épisodes)
+func (r *Renamer) GenerateEpisodeName(show *tmdb.Show, season int, episodes []int, media *mediainfo.MediaInfo, sourceType string) string {
+	parts := []string{r.showTitle(show), tmdb.EpisodeCode(season, episodes...)}
+	return r.releaseName(parts, media, sourceType)
+}
+
+// GenerateSeasonName génère le nom de release d'une saison complète
+// Format: Serie.S01.COMPLETE.Resolution.Source.VideoCodec.AudioCodec-GROUP
+func (r *Renamer) GenerateSeasonName(show *tmdb.Show, season int, media *mediainfo.MediaInfo, sourceType string) string {
+	parts := []string{r.showTitle(show), tmdb.EpisodeCode(season), "COMPLETE"}
+	return r.releaseName(parts, media, sourceType)
+}
+
+// showTitle retourne le titre nettoyé d'une série selon la politique de titre
+func (r *Renamer) showTitle(show *tmdb.Show) string {
+	return r.cleanTitle(r.chooseTitle(show.OriginalName, show.Name, show.NameIn("en")))
+}
+
+// chooseTitle applique la politique de titre. Un titre manquant est remplacé par le suivant
+// le plus proche: anglais, puis original en alphabet latin, puis localisé, puis original.
+func (r *Renamer) chooseTitle(original, localized, english string) string {
+	var candidates []string
+	switch r.titlePolicy {
+	case TitleOriginal:
+		candidates = []string{original}
+	case TitleLocalized:
+		candidates = []string{localized}
+	case TitleEnglish:
+		candidates = []string{english, latinOnly(original), localized}
+	default:
+		candidates = []string{latinOnly(original), english, latinOnly(localized)}
+	}
+	for _, title := range append(candidates, original, localized) {
+		if title != "" {
+			return title
+		}
+	}
+	return ""
+}
+
+// latinOnly retourne title s'il est écrit en alphabet latin, vide sinon
+func latinOnly(title string) string {
+	for _, c := range title {
+		if unicode.IsLetter(c) && !unicode.Is(unicode.Latin, c) {
+			return ""
+		}
+	}
+	return title
+}
+
+// releaseName complète le titre (parts) avec les langues et les informations techniques, puis le groupe
+func (r *Renamer) releaseName(parts []string, media *mediainfo.MediaInfo, sourceType string) string {
 	// Langue(s) détectée(s)
 	langs := r.detectLanguages(media)
 	if langs != "" {
@@ -0,0 +1,74 @@
+package renamer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/metwurcht/torrent-all-in-one/internal/mediainfo"
+	"github.com/metwurcht/torrent-all-in-one/internal/tmdb"
+)
+
+func TestGenerateTVNames(t *testing.T) {
+	show := &tmdb.Show{Name: "Le Bureau", OriginalName: "The Office (US)", FirstAirDate: "2005-03-24"}
+	media := &mediainfo.MediaInfo{
+		Video: mediainfo.VideoInfo{Codec: "AVC", Resolution: "1080p"},
+		Audio: []mediainfo.AudioInfo{{Codec: "E-AC-3", Channels: 6, Language: "en"}},
+	}
+	ren := NewRenamer("GRP")
+	audio := media.Audio[0].AudioCodecTag()
+
+	tests := []struct {
+		got, want string
+	}{
+		{ren.GenerateEpisodeName(show, 1, []int{2}, media, "WEB"), "The.Office.US.S01E02.ENGLISH.1080p.WEB.x264." + audio + ".5.1-GRP"},
+		{ren.GenerateEpisodeName(show, 3, []int{1, 2}, media, "WEB"), "The.Office.US.S03E01E02.ENGLISH.1080p.WEB.x264." + audio + ".5.1-GRP"},
+		{ren.GenerateSeasonName(show, 1, media, "WEB"), "The.Office.US.S01.COMPLETE.ENGLISH.1080p.WEB.x264." + audio + ".5.1-GRP"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("nom = %s, want %s", tt.got, tt.want)
+		}
+	}
+}
+
+func TestGenerateNameTitlePolicy(t *testing.T) {
+	movie := &tmdb.Movie{
+		Title:         "Le Voyage de Chihiro",
+		OriginalTitle: "千と千尋の神隠し",
+		ReleaseDate:   "2001-07-20",
+		Translations:  []tmdb.Translation{{Language: "en", Country: "US", Title: "Spirited Away"}},
+	}
+	latin := &tmdb.Movie{Title: "Le Fabuleux Destin d'Amélie Poulain", OriginalTitle: "Le Fabuleux Destin d'Amélie Poulain", ReleaseDate: "2001-04-25",
+		AlternativeTitles: []tmdb.AlternativeTitle{{Country: "US", Title: "Amelie"}}}
+	untranslated := &tmdb.Movie{Title: "Old Boy", OriginalTitle: "올드보이", ReleaseDate: "2003-11-21"}
+	media := &mediainfo.MediaInfo{Video: mediainfo.VideoInfo{Codec: "AVC", Resolution: "1080p"}}
+
+	tests := []struct {
+		policy TitlePolicy
+		movie  *tmdb.Movie
+		want   string
+	}{
+		{TitleOriginalIfLatin, movie, "Spirited.Away.2001"},
+		{TitleOriginalIfLatin, latin, "Le.Fabuleux.Destin.dAmélie.Poulain.2001"},
+		{TitleOriginalIfLatin, untranslated, "Old.Boy.2003"},
+		{TitleOriginal, movie, "千と千尋の神隠し.2001"},
+		{TitleEnglish, movie, "Spirited.Away.2001"},
+		{TitleEnglish, latin, "Amelie.2001"},
+		{TitleEnglish, untranslated, "Old.Boy.2003"},
+		{TitleLocalized, movie, "Le.Voyage.de.Chihiro.2001"},
+	}
+	for _, tt := range tests {
+		ren := NewRenamer("GRP")
+		ren.SetTitlePolicy(tt.policy)
+		if got := ren.GenerateName(tt.movie, media, "WEB"); !strings.HasPrefix(got, tt.want+".") {
+			t.Errorf("%s: nom = %s, want %s...", tt.policy, got, tt.want)
+		}
+	}
+
+	if p, err := ParseTitlePolicy(""); err != nil || p != TitleOriginal {
+		t.Errorf("politique par défaut = %q, %v", p, err)
+	}
+	if _, err := ParseTitlePolicy("romaji"); err == nil {
+		t.Error("une politique inconnue doit être refusée")
+	}
+}
@@ -0,0 +1,309 @@
+package seeder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	bt "github.com/anacrolix/torrent"
+	"github.com/anacrolix/torrent/metainfo"
+	"github.com/anacrolix/torrent/storage"
+	"golang.org/x/time/rate"
+)
+
+// defaultStatusInterval est l'intervalle par défaut entre deux statuts de seed
+const defaultStatusInterval = 10 * time.Second
+
+// StopReason indique pourquoi le seed s'est arrêté
+type StopReason string
+
+const (
+	// StopRatio: toutes les releases ont atteint le ratio demandé
+	StopRatio StopReason = "ratio"
+	// StopTime: la durée de seed est écoulée
+	StopTime StopReason = "durée"
+	// StopCancel: le seed a été interrompu (Ctrl+C)
+	StopCancel StopReason = "interruption"
+)
+
+// Options configure le client BitTorrent embarqué
+type Options struct {
+	ListenPort     int           // port d'écoute (0 = port libre choisi par le système)
+	UploadRate     int64         // limite d'envoi en octets/s (0 = illimité)
+	Ratio          float64       // ratio à atteindre avant de s'arrêter (0 = pas de limite)
+	Duration       time.Duration // durée maximale de seed (0 = pas de limite)
+	Peers          []string      // pairs (ip:port) à contacter directement, en plus des trackers
+	DHT            bool          // activer le DHT (inutile pour les torrents privés)
+	StatusInterval time.Duration // intervalle entre deux appels de Status
+
+	// Progress est appelée pendant la vérification des données (octets vérifiés, total)
+	Progress func(verified, total int64)
+	// Status est appelée périodiquement pendant le seed
+	Status func(Status)
+}
+
+// TorrentStatus décrit l'état d'un torrent en cours de seed
+type TorrentStatus struct {
+	Name     string
+	InfoHash string
+	Size     int64
+	Uploaded int64
+	Ratio    float64
+	Peers    int
+}
+
+// Status décrit l'état du seed à un instant donné
+type Status struct {
+	Elapsed    time.Duration
+	UploadRate float64 // octets/s depuis le statut précédent
+	Torrents   []TorrentStatus
+}
+
+// Summary résume une session de seed terminée
+type Summary struct {
+	Reason  StopReason
+	Elapsed time.Duration
+	Status  Status
+}
+
+// Seed vérifie les données puis les partage pour chaque torrent jusqu'à atteindre le ratio
+// ou la durée demandés, ou jusqu'à l'annulation du contexte.
+// Tous les torrents doivent décrire les mêmes données (un torrent par profil de tracker).
+// dataPath désigne les données elles-mêmes ou le dossier qui les contient.
+func Seed(ctx context.Context, torrentPaths []string, dataPath string, opts Options) (*Summary, error) {
+	if len(torrentPaths) == 0 {
+		return nil, fmt.Errorf("aucun torrent à seeder")
+	}
+
+	metas := make([]*metainfo.MetaInfo, len(torrentPaths))
+	var baseDir string
+	for i, path := range torrentPaths {
+		mi, err := metainfo.LoadFromFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("erreur lecture torrent %s: %w", path, err)
+		}
+		info, err := mi.UnmarshalInfo()
+		if err != nil {
+			return nil, fmt.Errorf("erreur lecture info %s: %w", path, err)
+		}
+		dir, err := dataBaseDir(info.BestName(), dataPath)
+		if err != nil {
+			return nil, err
+		}
+		if baseDir != "" && dir != baseDir {
+			return nil, fmt.Errorf("les torrents ne décrivent pas les mêmes données: %s", path)
+		}
+		baseDir = dir
+		metas[i] = mi
+	}
+
+	cl, err := newClient(baseDir, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cl.Close()
+
+	torrents := make([]*bt.Torrent, len(metas))
+	for i, mi := range metas {
+		t, err := cl.AddTorrent(mi)
+		if err != nil {
+			return nil, fmt.Errorf("erreur ajout torrent %s: %w", torrentPaths[i], err)
+		}
+		torrents[i] = t
+	}
+
+	if err := verify(ctx, torrents, opts.Progress); err != nil {
+		return nil, err
+	}
+
+	peers := make([]bt.PeerInfo, len(opts.Peers))
+	for i, addr := range opts.Peers {
+		peers[i] = bt.PeerInfo{Addr: bt.StringAddr(addr), Source: bt.PeerSourceDirect, Trusted: true}
+	}
+	for _, t := range torrents {
+		t.AddPeers(peers)
+	}
+
+	return seed(ctx, torrents, opts), nil
+}
+
+// newClient crée le client embarqué, avec les données lues depuis baseDir
+func newClient(baseDir string, opts Options) (*bt.Client, error) {
+	cfg := bt.NewDefaultClientConfig()
+	cfg.Seed = true
+	cfg.NoUpload = false
+	cfg.ListenPort = opts.ListenPort
+	cfg.NoDHT = !opts.DHT
+	cfg.DataDir = baseDir
+	// Les données existent déjà: l'état des pièces est recalculé à chaque lancement
+	cfg.DefaultStorage = storage.NewFileOpts(storage.NewFileClientOpts{
+		ClientBaseDir:   baseDir,
+		PieceCompletion: storage.NewMapPieceCompletion(),
+	})
+	if opts.UploadRate > 0 {
+		// La rafale borne la taille des requêtes acceptées (le client panique si une requête la
+		// dépasse): au moins 64 KiB, pour les blocs standard de 16 KiB comme pour les clients qui
+		// demandent des blocs plus grands
+		cfg.UploadRateLimiter = rate.NewLimiter(rate.Limit(opts.UploadRate), max(int(opts.UploadRate), 64<<10))
+	}
+	// Le client est très bavard: seules les erreurs sont affichées
+	cfg.Slogger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	cl, err := bt.NewClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("erreur démarrage client (port %d): %w", opts.ListenPort, err)
+	}
+	return cl, nil
+}
+
+// verify fait vérifier les pièces par le client et refuse de seeder des données incomplètes
+func verify(ctx context.Context, torrents []*bt.Torrent, progress func(verified, total int64)) error {
+	var total int64
+	for _, t := range torrents {
+		total += t.Length()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		for _, t := range torrents {
+			if err := t.VerifyDataContext(ctx); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case err := <-done:
+			if err != nil {
+				return fmt.Errorf("erreur vérification: %w", err)
+			}
+			if progress != nil {
+				progress(total, total)
+			}
+			for _, t := range torrents {
+				if missing := t.BytesMissing(); missing > 0 {
+					return fmt.Errorf("données incomplètes pour %s: %d octets invalides ou manquants", t.Name(), missing)
+				}
+			}
+			return nil
+		case <-ticker.C:
+			if progress != nil {
+				var verified int64
+				for _, t := range torrents {
+					verified += t.BytesCompleted()
+				}
+				progress(verified, total)
+			}
+		}
+	}
+}
+
+// seed partage les torrents jusqu'à ce qu'une condition d'arrêt soit atteinte
+func seed(ctx context.Context, torrents []*bt.Torrent, opts Options) *Summary {
+	interval := opts.StatusInterval
+	if interval <= 0 {
+		interval = defaultStatusInterval
+	}
+
+	start := time.Now()
+	var deadline <-chan time.Time
+	if opts.Duration > 0 {
+		timer := time.NewTimer(opts.Duration)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var status Status
+	var lastUploaded int64
+	lastTime := start
+	update := func() {
+		now := time.Now()
+		status = Status{Elapsed: now.Sub(start), Torrents: make([]TorrentStatus, len(torrents))}
+		var uploaded int64
+		for i, t := range torrents {
+			stats := t.Stats()
+			ts := TorrentStatus{
+				Name:     t.Name(),
+				InfoHash: t.InfoHash().HexString(),
+				Size:     t.Length(),
+				Uploaded: stats.BytesWrittenData.Int64(),
+				Peers:    stats.ActivePeers,
+			}
+			if ts.Size > 0 {
+				ts.Ratio = float64(ts.Uploaded) / float64(ts.Size)
+			}
+			status.Torrents[i] = ts
+			uploaded += ts.Uploaded
+		}
+		if elapsed := now.Sub(lastTime).Seconds(); elapsed > 0 {
+			status.UploadRate = float64(uploaded-lastUploaded) / elapsed
+		}
+		lastUploaded, lastTime = uploaded, now
+	}
+
+	finish := func(reason StopReason) *Summary {
+		update()
+		return &Summary{Reason: reason, Elapsed: status.Elapsed, Status: status}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return finish(StopCancel)
+		case <-deadline:
+			return finish(StopTime)
+		case <-ticker.C:
+			update()
+			if opts.Status != nil {
+				opts.Status(status)
+			}
+			if opts.Ratio > 0 && minRatio(status) >= opts.Ratio {
+				return finish(StopRatio)
+			}
+		}
+	}
+}
+
+// minRatio retourne le plus petit ratio parmi les torrents
+func minRatio(s Status) float64 {
+	ratio := math.Inf(1)
+	for _, t := range s.Torrents {
+		ratio = min(ratio, t.Ratio)
+	}
+	return ratio
+}
+
+// dataBaseDir retourne le dossier contenant les données nommées name.
+// dataPath désigne les données elles-mêmes ou le dossier qui les contient.
+func dataBaseDir(name, dataPath string) (string, error) {
+	abs, err := filepath.Abs(dataPath)
+	if err != nil {
+		return "", fmt.Errorf("chemin invalide: %w", err)
+	}
+
+	if filepath.Base(abs) == name {
+		if _, err := os.Stat(abs); err == nil {
+			return filepath.Dir(abs), nil
+		}
+	}
+	if _, err := os.Stat(filepath.Join(abs, name)); err == nil {
+		return abs, nil
+	}
+
+	if _, err := os.Stat(abs); errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("données introuvables: %s", dataPath)
+	}
+	return "", fmt.Errorf("données introuvables: %s ne contient pas %s", dataPath, name)
+}
@@ -0,0 +1,193 @@
+package seeder
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	bt "github.com/anacrolix/torrent"
+	"github.com/anacrolix/torrent/metainfo"
+	"github.com/metwurcht/torrent-all-in-one/internal/torrent"
+)
+
+// makeRelease crée une release de deux fichiers et son torrent
+func makeRelease(t *testing.T, version torrent.Version) (dataDir, torrentPath string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	dataDir = filepath.Join(dir, "Release.2024")
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	rng := rand.New(rand.NewSource(1))
+	for name, size := range map[string]int{"film.mkv": 700 << 10, "film.nfo": 3000} {
+		data := make([]byte, size)
+		rng.Read(data)
+		if err := os.WriteFile(filepath.Join(dataDir, name), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	g := torrent.NewGenerator()
+	g.SetVersion(version)
+	g.SetPieceSize(64 << 10)
+	torrentPath = filepath.Join(dir, "release.torrent")
+	if err := g.Create(context.Background(), dataDir, torrentPath); err != nil {
+		t.Fatal(err)
+	}
+	return dataDir, torrentPath
+}
+
+// newLeecher démarre un client qui télécharge le torrent dans un dossier temporaire
+func newLeecher(t *testing.T, torrentPath string) (*bt.Client, *bt.Torrent, string) {
+	t.Helper()
+
+	cfg := bt.NewDefaultClientConfig()
+	cfg.DataDir = t.TempDir()
+	cfg.ListenPort = 0
+	cfg.NoDHT = true
+	cfg.DisableTrackers = true
+	cfg.Slogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	cl, err := bt.NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cl.Close() })
+
+	mi, err := metainfo.LoadFromFile(torrentPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tor, err := cl.AddTorrent(mi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tor.DownloadAll()
+	return cl, tor, cfg.DataDir
+}
+
+func TestSeedRatio(t *testing.T) {
+	for _, version := range []torrent.Version{torrent.VersionV1, torrent.VersionHybrid} {
+		t.Run(string(version), func(t *testing.T) {
+			dataDir, torrentPath := makeRelease(t, version)
+			leecher, tor, leechDir := newLeecher(t, torrentPath)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			var verified, total int64
+			summary, err := Seed(ctx, []string{torrentPath}, dataDir, Options{
+				Ratio:          1,
+				Peers:          []string{fmt.Sprintf("127.0.0.1:%d", leecher.LocalPort())},
+				StatusInterval: 50 * time.Millisecond,
+				Progress:       func(v, tt int64) { verified, total = v, tt },
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if summary.Reason != StopRatio {
+				t.Fatalf("arrêt = %s, attendu %s", summary.Reason, StopRatio)
+			}
+			if verified != total || total != tor.Length() {
+				t.Errorf("vérification = %d / %d, attendu %d", verified, total, tor.Length())
+			}
+			if got := summary.Status.Torrents[0]; got.Ratio < 1 || got.Uploaded < got.Size {
+				t.Errorf("statut = %+v, ratio >= 1 attendu", got)
+			}
+
+			select {
+			case <-tor.Complete().On():
+			case <-ctx.Done():
+				t.Fatal("téléchargement incomplet")
+			}
+			for _, name := range []string{"film.mkv", "film.nfo"} {
+				want, _ := os.ReadFile(filepath.Join(dataDir, name))
+				got, err := os.ReadFile(filepath.Join(leechDir, "Release.2024", name))
+				if err != nil || !bytes.Equal(got, want) {
+					t.Errorf("%s: contenu téléchargé différent (%v)", name, err)
+				}
+			}
+		})
+	}
+}
+
+func TestSeedDuration(t *testing.T) {
+	dataDir, torrentPath := makeRelease(t, torrent.VersionV1)
+
+	// Le dossier parent des données est aussi accepté
+	summary, err := Seed(context.Background(), []string{torrentPath}, filepath.Dir(dataDir), Options{
+		Duration:       200 * time.Millisecond,
+		StatusInterval: 50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Reason != StopTime {
+		t.Errorf("arrêt = %s, attendu %s", summary.Reason, StopTime)
+	}
+	if summary.Status.Torrents[0].Uploaded != 0 {
+		t.Errorf("envoyé = %d sans pair, attendu 0", summary.Status.Torrents[0].Uploaded)
+	}
+}
+
+func TestSeedCancel(t *testing.T) {
+	dataDir, torrentPath := makeRelease(t, torrent.VersionV1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	statuses := 0
+	summary, err := Seed(ctx, []string{torrentPath}, dataDir, Options{
+		StatusInterval: 20 * time.Millisecond,
+		Status: func(Status) {
+			if statuses++; statuses == 3 {
+				cancel()
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Reason != StopCancel {
+		t.Errorf("arrêt = %s, attendu %s", summary.Reason, StopCancel)
+	}
+}
+
+func TestSeedIncompleteData(t *testing.T) {
+	dataDir, torrentPath := makeRelease(t, torrent.VersionV1)
+
+	f, err := os.OpenFile(filepath.Join(dataDir, "film.mkv"), os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteAt([]byte("corrompu"), 100<<10)
+	f.Close()
+
+	_, err = Seed(context.Background(), []string{torrentPath}, dataDir, Options{Duration: time.Second})
+	if err == nil || !strings.Contains(err.Error(), "données incomplètes") {
+		t.Fatalf("erreur = %v, données incomplètes attendu", err)
+	}
+}
+
+func TestDataBaseDir(t *testing.T) {
+	dataDir, _ := makeRelease(t, torrent.VersionV1)
+	parent := filepath.Dir(dataDir)
+
+	for _, path := range []string{dataDir, parent} {
+		got, err := dataBaseDir("Release.2024", path)
+		if err != nil || got != parent {
+			t.Errorf("dataBaseDir(%s) = %q, %v, attendu %q", path, got, err, parent)
+		}
+	}
+
+	if _, err := dataBaseDir("Autre.Release", parent); err == nil {
+		t.Error("erreur attendue pour des données absentes")
+	}
+}
@@ -0,0 +1,368 @@
+package tmdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const apiBaseURL = "https://api.themoviedb.org"
+
+// APIClient interroge l'API officielle TMDB v3 (JSON)
+type APIClient struct {
+	httpClient *http.Client
+	baseURL    string
+	apiKey     string
+	token      string
+	language   string
+}
+
+// newAPIClient crée un client de l'API TMDB v3 à partir de la configuration
+func newAPIClient(cfg Config) (*APIClient, error) {
+	if cfg.APIKey == "" && cfg.Token == "" {
+		return nil, fmt.Errorf("backend TMDB api: clé d'API (tmdb.api_key) ou jeton (tmdb.token) manquant")
+	}
+
+	c := &APIClient{
+		httpClient: newHTTPClient(cfg),
+		baseURL:    apiBaseURL,
+		apiKey:     cfg.APIKey,
+		token:      cfg.Token,
+		language:   DefaultLanguage,
+	}
+	if cfg.URL != "" {
+		c.baseURL = strings.TrimRight(cfg.URL, "/")
+	}
+	if cfg.Language != "" {
+		c.language = cfg.Language
+	}
+	return c, nil
+}
+
+// Name implémente Provider
+func (c *APIClient) Name() string {
+	return BackendAPI
+}
+
+// apiMovie est un film tel que retourné par /search/movie et /movie/{id}
+type apiMovie struct {
+	ID            int     `json:"id"`
+	Title         string  `json:"title"`
+	OriginalTitle string  `json:"original_title"`
+	Overview      string  `json:"overview"`
+	ReleaseDate   string  `json:"release_date"`
+	PosterPath    string  `json:"poster_path"`
+	BackdropPath  string  `json:"backdrop_path"`
+	VoteAverage   float64 `json:"vote_average"`
+	VoteCount     int     `json:"vote_count"`
+	Runtime       int     `json:"runtime"`
+	Budget        int64   `json:"budget"`
+	Revenue       int64   `json:"revenue"`
+	Tagline       string  `json:"tagline"`
+	IMDbID        string  `json:"imdb_id"`
+	Genres        []struct {
+		Name string `json:"name"`
+	} `json:"genres"`
+	ProductionCompanies []struct {
+		Name string `json:"name"`
+	} `json:"production_companies"`
+	Credits struct {
+		Cast []CastMember `json:"cast"`
+		Crew []struct {
+			Name string `json:"name"`
+			Job  string `json:"job"`
+		} `json:"crew"`
+	} `json:"credits"`
+	AlternativeTitles struct {
+		Titles []apiAlternativeTitle `json:"titles"`
+	} `json:"alternative_titles"`
+	Translations apiTranslations `json:"translations"`
+}
+
+// movie convertit la réponse de l'API en Movie
+func (m *apiMovie) movie() Movie {
+	movie := Movie{
+		ID:            m.ID,
+		Title:         m.Title,
+		OriginalTitle: m.OriginalTitle,
+		Overview:      m.Overview,
+		ReleaseDate:   m.ReleaseDate,
+		PosterPath:    m.PosterPath,
+		BackdropPath:  m.BackdropPath,
+		VoteAverage:   m.VoteAverage,
+		VoteCount:     m.VoteCount,
+		Runtime:       m.Runtime,
+		Budget:        m.Budget,
+		Revenue:       m.Revenue,
+		Tagline:       m.Tagline,
+		IMDbID:        m.IMDbID,
+	}
+	if movie.OriginalTitle == "" {
+		movie.OriginalTitle = movie.Title
+	}
+
+	for _, g := range m.Genres {
+		movie.Genres = append(movie.Genres, g.Name)
+	}
+	for _, p := range m.ProductionCompanies {
+		movie.ProductionCompanies = append(movie.ProductionCompanies, p.Name)
+	}
+	// Même limite que la page du film: les 10 premiers rôles
+	for _, member := range m.Credits.Cast {
+		if len(movie.Cast) >= 10 {
+			break
+		}
+		movie.Cast = append(movie.Cast, member)
+	}
+	for _, member := range m.Credits.Crew {
+		if member.Job == "Director" {
+			movie.Directors = append(movie.Directors, member.Name)
+		}
+	}
+	movie.Translations = m.Translations.translations()
+	movie.AlternativeTitles = alternativeTitles(m.AlternativeTitles.Titles)
+	return movie
+}
+
+// apiAlternativeTitle est un titre alternatif de append_to_response=alternative_titles
+type apiAlternativeTitle struct {
+	Country string `json:"iso_3166_1"`
+	Title   string `json:"title"`
+	Type    string `json:"type"`
+}
+
+// alternativeTitles convertit les titres alternatifs de l'API
+func alternativeTitles(titles []apiAlternativeTitle) []AlternativeTitle {
+	var result []AlternativeTitle
+	for _, t := range titles {
+		result = append(result, AlternativeTitle(t))
+	}
+	return result
+}
+
+// apiTranslations est le bloc de append_to_response=translations
+type apiTranslations struct {
+	Translations []struct {
+		Country  string `json:"iso_3166_1"`
+		Language string `json:"iso_639_1"`
+		Data     struct {
+			Title string `json:"title"` // films
+			Name  string `json:"name"`  // séries
+		} `json:"data"`
+	} `json:"translations"`
+}
+
+// translations retourne les titres traduits; un titre vide signifie que la traduction garde le titre original
+func (t apiTranslations) translations() []Translation {
+	var result []Translation
+	for _, tr := range t.Translations {
+		title := tr.Data.Title
+		if title == "" {
+			title = tr.Data.Name
+		}
+		if title != "" {
+			result = append(result, Translation{Language: tr.Language, Country: tr.Country, Title: title})
+		}
+	}
+	return result
+}
+
+// SearchMovie recherche des films par mots-clés via /search/movie
+func (c *APIClient) SearchMovie(ctx context.Context, query string) ([]Movie, error) {
+	params := url.Values{"query": {query}, "include_adult": {"false"}}
+
+	var page struct {
+		Results []apiMovie `json:"results"`
+	}
+	if err := c.get(ctx, "/3/search/movie", params, &page); err != nil {
+		return nil, err
+	}
+
+	movies := make([]Movie, 0, len(page.Results))
+	for _, result := range page.Results {
+		movies = append(movies, result.movie())
+	}
+	return movies, nil
+}
+
+// GetMovieDetails récupère les détails d'un film via /movie/{id}, avec le casting, l'équipe,
+// les titres alternatifs et les traductions
+func (c *APIClient) GetMovieDetails(ctx context.Context, id int) (*Movie, error) {
+	params := url.Values{"append_to_response": {"credits,alternative_titles,translations"}}
+
+	var details apiMovie
+	if err := c.get(ctx, "/3/movie/"+strconv.Itoa(id), params, &details); err != nil {
+		return nil, err
+	}
+
+	movie := details.movie()
+	return &movie, nil
+}
+
+// apiShow est une série telle que retournée par /search/tv et /tv/{id}
+type apiShow struct {
+	ID              int     `json:"id"`
+	Name            string  `json:"name"`
+	OriginalName    string  `json:"original_name"`
+	Overview        string  `json:"overview"`
+	FirstAirDate    string  `json:"first_air_date"`
+	PosterPath      string  `json:"poster_path"`
+	BackdropPath    string  `json:"backdrop_path"`
+	VoteAverage     float64 `json:"vote_average"`
+	VoteCount       int     `json:"vote_count"`
+	EpisodeRunTime  []int   `json:"episode_run_time"`
+	NumberOfSeasons int     `json:"number_of_seasons"`
+	Tagline         string  `json:"tagline"`
+	Genres          []struct {
+		Name string `json:"name"`
+	} `json:"genres"`
+	Networks []struct {
+		Name string `json:"name"`
+	} `json:"networks"`
+	CreatedBy []struct {
+		Name string `json:"name"`
+	} `json:"created_by"`
+	Credits struct {
+		Cast []CastMember `json:"cast"`
+	} `json:"credits"`
+	ExternalIDs struct {
+		IMDbID string `json:"imdb_id"`
+	} `json:"external_ids"`
+	AlternativeTitles struct {
+		Results []apiAlternativeTitle `json:"results"`
+	} `json:"alternative_titles"`
+	Translations apiTranslations `json:"translations"`
+}
+
+// show convertit la réponse de l'API en Show
+func (s *apiShow) show() Show {
+	show := Show{
+		ID:              s.ID,
+		Name:            s.Name,
+		OriginalName:    s.OriginalName,
+		Overview:        s.Overview,
+		FirstAirDate:    s.FirstAirDate,
+		PosterPath:      s.PosterPath,
+		BackdropPath:    s.BackdropPath,
+		VoteAverage:     s.VoteAverage,
+		VoteCount:       s.VoteCount,
+		NumberOfSeasons: s.NumberOfSeasons,
+		Tagline:         s.Tagline,
+		IMDbID:          s.ExternalIDs.IMDbID,
+	}
+	if show.OriginalName == "" {
+		show.OriginalName = show.Name
+	}
+	if len(s.EpisodeRunTime) > 0 {
+		show.EpisodeRuntime = s.EpisodeRunTime[0]
+	}
+
+	for _, g := range s.Genres {
+		show.Genres = append(show.Genres, g.Name)
+	}
+	for _, n := range s.Networks {
+		show.Networks = append(show.Networks, n.Name)
+	}
+	for _, c := range s.CreatedBy {
+		show.Creators = append(show.Creators, c.Name)
+	}
+	for _, member := range s.Credits.Cast {
+		if len(show.Cast) >= 10 {
+			break
+		}
+		show.Cast = append(show.Cast, member)
+	}
+	show.Translations = s.Translations.translations()
+	show.AlternativeTitles = alternativeTitles(s.AlternativeTitles.Results)
+	return show
+}
+
+// SearchTV recherche des séries par mots-clés via /search/tv
+func (c *APIClient) SearchTV(ctx context.Context, query string) ([]Show, error) {
+	params := url.Values{"query": {query}, "include_adult": {"false"}}
+
+	var page struct {
+		Results []apiShow `json:"results"`
+	}
+	if err := c.get(ctx, "/3/search/tv", params, &page); err != nil {
+		return nil, err
+	}
+
+	shows := make([]Show, 0, len(page.Results))
+	for _, result := range page.Results {
+		shows = append(shows, result.show())
+	}
+	return shows, nil
+}
+
+// GetTVDetails récupère les détails d'une série via /tv/{id}, avec le casting, l'identifiant IMDb,
+// les titres alternatifs et les traductions
+func (c *APIClient) GetTVDetails(ctx context.Context, id int) (*Show, error) {
+	params := url.Values{"append_to_response": {"credits,external_ids,alternative_titles,translations"}}
+
+	var details apiShow
+	if err := c.get(ctx, "/3/tv/"+strconv.Itoa(id), params, &details); err != nil {
+		return nil, err
+	}
+
+	show := details.show()
+	return &show, nil
+}
+
+// GetSeason récupère une saison et ses épisodes via /tv/{id}/season/{n}
+func (c *APIClient) GetSeason(ctx context.Context, showID, season int) (*Season, error) {
+	var result Season
+	path := fmt.Sprintf("/3/tv/%d/season/%d", showID, season)
+	if err := c.get(ctx, path, url.Values{}, &result); err != nil {
+		return nil, err
+	}
+
+	result.ShowID = showID
+	for i := range result.Episodes {
+		if result.Episodes[i].SeasonNumber == 0 {
+			result.Episodes[i].SeasonNumber = result.SeasonNumber
+		}
+	}
+	return &result, nil
+}
+
+// get effectue une requête GET authentifiée et décode la réponse JSON dans out
+func (c *APIClient) get(ctx context.Context, path string, params url.Values, out interface{}) error {
+	params.Set("language", c.language)
+	if c.token == "" {
+		params.Set("api_key", c.apiKey)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return requestError(ctx, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// Les erreurs de l'API sont décrites par {"status_code": 7, "status_message": "..."}
+		var apiErr struct {
+			StatusMessage string `json:"status_message"`
+		}
+		json.NewDecoder(resp.Body).Decode(&apiErr)
+		return statusError(resp, apiErr.StatusMessage)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("erreur décodage réponse TMDB: %w", err)
+	}
+	return nil
+}
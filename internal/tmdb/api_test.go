@@ -0,0 +1,248 @@
+package tmdb
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newFakeAPI démarre un faux serveur de l'API TMDB v3 qui exige l'authentification donnée
+func newFakeAPI(t *testing.T, apiKey, token string) *httptest.Server {
+	t.Helper()
+
+	authorized := func(w http.ResponseWriter, r *http.Request) bool {
+		if (apiKey != "" && r.URL.Query().Get("api_key") == apiKey) ||
+			(token != "" && r.Header.Get("Authorization") == "Bearer "+token) {
+			return true
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		io.WriteString(w, `{"status_code": 7, "status_message": "Invalid API key: You must be granted a valid key.", "success": false}`)
+		return false
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/3/search/movie", func(w http.ResponseWriter, r *http.Request) {
+		if !authorized(w, r) {
+			return
+		}
+		if r.URL.Query().Get("query") != "the matrix" || r.URL.Query().Get("language") != "fr-FR" {
+			t.Errorf("paramètres de recherche = %s", r.URL.RawQuery)
+		}
+		io.WriteString(w, `{"page": 1, "results": [
+			{"id": 603, "title": "Matrix", "original_title": "The Matrix", "release_date": "1999-03-30", "poster_path": "/matrix.jpg", "vote_average": 8.2},
+			{"id": 604, "title": "Matrix Reloaded", "original_title": "The Matrix Reloaded", "release_date": "2003-05-15"}
+		], "total_results": 2}`)
+	})
+	mux.HandleFunc("/3/movie/603", func(w http.ResponseWriter, r *http.Request) {
+		if !authorized(w, r) {
+			return
+		}
+		if r.URL.Query().Get("append_to_response") != "credits,alternative_titles,translations" {
+			t.Errorf("append_to_response = %q", r.URL.Query().Get("append_to_response"))
+		}
+		io.WriteString(w, `{
+			"id": 603, "title": "Matrix", "original_title": "The Matrix", "release_date": "1999-03-30",
+			"runtime": 136, "tagline": "Bienvenue dans le monde réel.", "imdb_id": "tt0133093",
+			"genres": [{"id": 28, "name": "Action"}, {"id": 878, "name": "Science-Fiction"}],
+			"production_companies": [{"id": 79, "name": "Village Roadshow Pictures"}],
+			"credits": {
+				"cast": [{"name": "Keanu Reeves", "character": "Neo", "order": 0, "profile_path": "/keanu.jpg"}],
+				"crew": [
+					{"name": "Lilly Wachowski", "job": "Director"},
+					{"name": "Lana Wachowski", "job": "Director"},
+					{"name": "Joel Silver", "job": "Producer"}
+				]
+			},
+			"alternative_titles": {"titles": [{"iso_3166_1": "JP", "title": "マトリックス", "type": ""}]},
+			"translations": {"translations": [
+				{"iso_3166_1": "US", "iso_639_1": "en", "data": {"title": "", "overview": "..."}},
+				{"iso_3166_1": "FR", "iso_639_1": "fr", "data": {"title": "Matrix", "overview": "..."}}
+			]}
+		}`)
+	})
+	mux.HandleFunc("/3/search/tv", func(w http.ResponseWriter, r *http.Request) {
+		if !authorized(w, r) {
+			return
+		}
+		io.WriteString(w, `{"page": 1, "results": [
+			{"id": 1396, "name": "Breaking Bad", "original_name": "Breaking Bad", "first_air_date": "2008-01-20"}
+		]}`)
+	})
+	mux.HandleFunc("/3/tv/1396", func(w http.ResponseWriter, r *http.Request) {
+		if !authorized(w, r) {
+			return
+		}
+		if r.URL.Query().Get("append_to_response") != "credits,external_ids,alternative_titles,translations" {
+			t.Errorf("append_to_response = %q", r.URL.Query().Get("append_to_response"))
+		}
+		io.WriteString(w, `{
+			"id": 1396, "name": "Breaking Bad", "original_name": "Breaking Bad", "first_air_date": "2008-01-20",
+			"episode_run_time": [45, 47], "number_of_seasons": 5,
+			"genres": [{"name": "Drame"}], "networks": [{"name": "AMC"}], "created_by": [{"name": "Vince Gilligan"}],
+			"credits": {"cast": [{"name": "Bryan Cranston", "character": "Walter White", "order": 0}]},
+			"external_ids": {"imdb_id": "tt0903747"},
+			"alternative_titles": {"results": [{"iso_3166_1": "GB", "title": "Breaking Bad (UK)", "type": ""}]},
+			"translations": {"translations": [{"iso_3166_1": "DE", "iso_639_1": "de", "data": {"name": "Breaking Bad – Die Serie"}}]}
+		}`)
+	})
+	mux.HandleFunc("/3/tv/1396/season/1", func(w http.ResponseWriter, r *http.Request) {
+		if !authorized(w, r) {
+			return
+		}
+		io.WriteString(w, `{
+			"season_number": 1, "name": "Saison 1", "air_date": "2008-01-20",
+			"episodes": [
+				{"episode_number": 1, "season_number": 1, "name": "Chute libre", "air_date": "2008-01-20", "runtime": 58},
+				{"episode_number": 2, "season_number": 1, "name": "Le Choix", "air_date": "2008-01-27", "runtime": 48}
+			]
+		}`)
+	})
+	mux.HandleFunc("/3/movie/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, `{"status_code": 34, "status_message": "The resource you requested could not be found.", "success": false}`)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestAPIClient(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{"api_key", Config{APIKey: "KEY"}},
+		{"bearer", Config{Backend: "api", Token: "TOKEN"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeAPI(t, "KEY", "TOKEN")
+			tt.cfg.URL = server.URL
+			provider, err := New(tt.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if provider.Name() != BackendAPI {
+				t.Fatalf("backend = %s, want api", provider.Name())
+			}
+
+			movies, err := provider.SearchMovie(context.Background(), "the matrix")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(movies) != 2 || movies[0].ID != 603 || movies[0].OriginalTitle != "The Matrix" || movies[0].Year() != "1999" {
+				t.Errorf("résultats = %+v", movies)
+			}
+
+			movie, err := provider.GetMovieDetails(context.Background(), 603)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if movie.Runtime != 136 || movie.IMDbID != "tt0133093" || movie.Tagline == "" {
+				t.Errorf("détails = %+v", movie)
+			}
+			if want := []string{"Action", "Science-Fiction"}; !reflect.DeepEqual(movie.Genres, want) {
+				t.Errorf("genres = %v, want %v", movie.Genres, want)
+			}
+			if want := []string{"Lilly Wachowski", "Lana Wachowski"}; !reflect.DeepEqual(movie.Directors, want) {
+				t.Errorf("réalisateurs = %v, want %v", movie.Directors, want)
+			}
+			if len(movie.Cast) != 1 || movie.Cast[0].Character != "Neo" || movie.Cast[0].ProfilePath != "/keanu.jpg" {
+				t.Errorf("casting = %+v", movie.Cast)
+			}
+			// Une traduction sans titre garde le titre original: elle est ignorée
+			if got := movie.TitleIn("fr-FR"); got != "Matrix" {
+				t.Errorf("titre fr = %q", got)
+			}
+			if got := movie.TitleIn("ja"); got != "" {
+				t.Errorf("titre ja = %q, aucun pays associé", got)
+			}
+			if got := movie.TitleIn("ja-JP"); got != "マトリックス" {
+				t.Errorf("titre ja-JP = %q", got)
+			}
+			if got := movie.TitleIn("en"); got != "" {
+				t.Errorf("titre en = %q, want vide", got)
+			}
+		})
+	}
+}
+
+func TestAPIClientErrors(t *testing.T) {
+	server := newFakeAPI(t, "KEY", "")
+
+	provider, _ := New(Config{APIKey: "WRONG", URL: server.URL})
+	if _, err := provider.SearchMovie(context.Background(), "the matrix"); err == nil || !strings.Contains(err.Error(), "Invalid API key") {
+		t.Errorf("erreur = %v, attendu le message de l'API", err)
+	}
+
+	provider, _ = New(Config{APIKey: "KEY", URL: server.URL})
+	if _, err := provider.GetMovieDetails(context.Background(), 1); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("erreur = %v, attendu 404", err)
+	}
+}
+
+func TestNewBackend(t *testing.T) {
+	provider, err := New(Config{})
+	if err != nil || provider.Name() != BackendScraper {
+		t.Errorf("sans clé: backend = %v, %v, want scraper", provider, err)
+	}
+	provider, err = New(Config{Backend: "scraper", APIKey: "KEY"})
+	if err != nil || provider.Name() != BackendScraper {
+		t.Errorf("backend explicite: %v, %v, want scraper", provider, err)
+	}
+	if _, err := New(Config{Backend: "api"}); err == nil {
+		t.Error("le backend api sans clé ni jeton doit être refusé")
+	}
+	if _, err := New(Config{Backend: "imdb"}); err == nil {
+		t.Error("un backend inconnu doit être refusé")
+	}
+}
+
+func TestAPIClientTV(t *testing.T) {
+	server := newFakeAPI(t, "KEY", "")
+	provider, err := New(Config{APIKey: "KEY", URL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shows, err := provider.SearchTV(context.Background(), "breaking bad")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shows) != 1 || shows[0].ID != 1396 || shows[0].Year() != "2008" {
+		t.Errorf("résultats = %+v", shows)
+	}
+
+	show, err := provider.GetTVDetails(context.Background(), 1396)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if show.IMDbID != "tt0903747" || show.EpisodeRuntime != 45 || show.NumberOfSeasons != 5 ||
+		!reflect.DeepEqual(show.Networks, []string{"AMC"}) || !reflect.DeepEqual(show.Creators, []string{"Vince Gilligan"}) ||
+		len(show.Cast) != 1 {
+		t.Errorf("détails = %+v", show)
+	}
+	if show.NameIn("en-US") != "Breaking Bad (UK)" || show.NameIn("de") != "Breaking Bad – Die Serie" {
+		t.Errorf("titres = %+v, %+v", show.Translations, show.AlternativeTitles)
+	}
+
+	season, err := provider.GetSeason(context.Background(), 1396, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if season.ShowID != 1396 || len(season.Episodes) != 2 {
+		t.Fatalf("saison = %+v", season)
+	}
+	if e := season.Episode(2); e == nil || e.Name != "Le Choix" || e.Code() != "S01E02" {
+		t.Errorf("épisode 2 = %+v", e)
+	}
+	if season.Episode(3) != nil {
+		t.Error("l'épisode 3 n'existe pas dans la saison")
+	}
+}
@@ -0,0 +1,216 @@
+package tmdb
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cacheExt est l'extension des fichiers du cache de métadonnées
+const cacheExt = ".json"
+
+// cacheVersion fait partie de la clé des entrées: l'incrémenter quand les données mises en cache
+// changent de forme, pour que les anciennes entrées soient ignorées au lieu d'être relues incomplètes.
+// Version 2: les fiches contiennent les traductions et les titres alternatifs.
+const cacheVersion = "2"
+
+// CachedProvider conserve sur disque les recherches et les fiches d'un Provider pendant une durée
+// limitée. Une entrée est identifiée par le backend, la langue, le type de requête et la requête
+// (mots-clés ou ID). Une entrée expirée sert encore de secours si TMDB est indisponible.
+type CachedProvider struct {
+	provider Provider
+	dir      string
+	language string
+	ttl      time.Duration
+	refresh  bool
+	now      func() time.Time
+}
+
+// cacheEntry est le contenu d'un fichier du cache
+type cacheEntry struct {
+	Stored time.Time       `json:"stored"`
+	Key    string          `json:"key"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// NewCachedProvider ajoute un cache disque dans dir devant provider.
+// language fait partie de la clé: les réponses de TMDB dépendent de la langue demandée.
+func NewCachedProvider(provider Provider, dir, language string, ttl time.Duration) (*CachedProvider, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("erreur création dossier cache: %w", err)
+	}
+	return &CachedProvider{provider: provider, dir: dir, language: language, ttl: ttl, now: time.Now}, nil
+}
+
+// SetRefresh ignore les entrées du cache: chaque requête interroge TMDB et met le cache à jour
+func (c *CachedProvider) SetRefresh(refresh bool) {
+	c.refresh = refresh
+}
+
+// Name retourne le nom du backend mis en cache
+func (c *CachedProvider) Name() string {
+	return c.provider.Name()
+}
+
+// SearchMovie recherche des films par mots-clés
+func (c *CachedProvider) SearchMovie(ctx context.Context, query string) ([]Movie, error) {
+	return cached(c, "search/movie", normalizeQuery(query), func() ([]Movie, error) {
+		return c.provider.SearchMovie(ctx, query)
+	})
+}
+
+// GetMovieDetails récupère les détails complets d'un film
+func (c *CachedProvider) GetMovieDetails(ctx context.Context, id int) (*Movie, error) {
+	return cached(c, "movie", strconv.Itoa(id), func() (*Movie, error) {
+		return c.provider.GetMovieDetails(ctx, id)
+	})
+}
+
+// SearchTV recherche des séries par mots-clés
+func (c *CachedProvider) SearchTV(ctx context.Context, query string) ([]Show, error) {
+	return cached(c, "search/tv", normalizeQuery(query), func() ([]Show, error) {
+		return c.provider.SearchTV(ctx, query)
+	})
+}
+
+// GetTVDetails récupère les détails complets d'une série
+func (c *CachedProvider) GetTVDetails(ctx context.Context, id int) (*Show, error) {
+	return cached(c, "tv", strconv.Itoa(id), func() (*Show, error) {
+		return c.provider.GetTVDetails(ctx, id)
+	})
+}
+
+// GetSeason récupère une saison d'une série et la liste de ses épisodes
+func (c *CachedProvider) GetSeason(ctx context.Context, showID, season int) (*Season, error) {
+	return cached(c, "season", fmt.Sprintf("%d/%d", showID, season), func() (*Season, error) {
+		return c.provider.GetSeason(ctx, showID, season)
+	})
+}
+
+// cached retourne l'entrée en cache si elle est encore valide, sinon interroge TMDB avec fetch
+// et enregistre la réponse. Si TMDB est indisponible, une entrée expirée est préférée à l'erreur.
+func cached[T any](c *CachedProvider, kind, query string, fetch func() (T, error)) (T, error) {
+	key := strings.Join([]string{cacheVersion, c.provider.Name(), c.language, kind, query}, "\x00")
+	path := filepath.Join(c.dir, cacheID(key)+cacheExt)
+
+	var value T
+	entry, found := c.read(path, key)
+	if found && !c.refresh && c.now().Sub(entry.Stored) < c.ttl {
+		if err := json.Unmarshal(entry.Data, &value); err == nil {
+			return value, nil
+		}
+		found = false
+	}
+
+	value, err := fetch()
+	if err != nil {
+		var stale T
+		if found && errors.Is(err, ErrUnavailable) && json.Unmarshal(entry.Data, &stale) == nil {
+			return stale, nil
+		}
+		return value, err
+	}
+
+	// Une recherche sans résultat n'est pas conservée: le titre peut être ajouté à TMDB entre-temps.
+	// Une erreur d'écriture du cache ne doit pas faire échouer l'identification.
+	if !emptyResult(value) {
+		c.write(path, key, value)
+	}
+	return value, nil
+}
+
+// emptyResult indique si une réponse est vide (liste sans résultat ou fiche absente)
+func emptyResult(value any) bool {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Slice:
+		return v.Len() == 0
+	case reflect.Pointer:
+		return v.IsNil()
+	}
+	return false
+}
+
+// read charge l'entrée du fichier path si elle correspond bien à key
+func (c *CachedProvider) read(path, key string) (cacheEntry, bool) {
+	var entry cacheEntry
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return entry, false
+	}
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Key != key {
+		return entry, false
+	}
+	return entry, true
+}
+
+// write enregistre value dans le fichier path (écriture atomique)
+func (c *CachedProvider) write(path, key string, value any) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(cacheEntry{Stored: c.now(), Key: key, Data: raw})
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// ClearCache supprime toutes les entrées du cache de métadonnées de dir et retourne leur nombre
+func ClearCache(dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("erreur lecture cache: %w", err)
+	}
+
+	removed := 0
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), cacheExt) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("erreur suppression cache: %w", err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// cacheID retourne le nom de fichier d'une clé de cache
+func cacheID(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:16])
+}
+
+// normalizeQuery rend équivalentes les recherches qui ne diffèrent que par la casse ou les espaces
+func normalizeQuery(query string) string {
+	return strings.ToLower(strings.Join(strings.Fields(query), " "))
+}
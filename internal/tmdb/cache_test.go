@@ -0,0 +1,134 @@
+package tmdb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// countingProvider est un faux backend qui compte les requêtes reçues
+type countingProvider struct {
+	calls int
+	err   error
+}
+
+func (p *countingProvider) Name() string { return "fake" }
+
+func (p *countingProvider) SearchMovie(ctx context.Context, query string) ([]Movie, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return []Movie{{ID: 603, OriginalTitle: "The Matrix", ReleaseDate: "1999-03-30"}}, nil
+}
+
+func (p *countingProvider) GetMovieDetails(ctx context.Context, id int) (*Movie, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &Movie{ID: id, Runtime: 136, Genres: []string{"Action"}}, nil
+}
+
+func (p *countingProvider) SearchTV(ctx context.Context, query string) ([]Show, error) {
+	p.calls++
+	return nil, p.err
+}
+
+func (p *countingProvider) GetTVDetails(ctx context.Context, id int) (*Show, error) {
+	p.calls++
+	return &Show{ID: id}, p.err
+}
+
+func (p *countingProvider) GetSeason(ctx context.Context, showID, season int) (*Season, error) {
+	p.calls++
+	return &Season{ShowID: showID, SeasonNumber: season, Episodes: []Episode{{SeasonNumber: season, EpisodeNumber: 1}}}, p.err
+}
+
+func TestCachedProvider(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	backend := &countingProvider{}
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	open := func(language string) *CachedProvider {
+		c, err := NewCachedProvider(backend, dir, language, time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.now = func() time.Time { return now }
+		return c
+	}
+
+	c := open("fr-FR")
+	if _, err := c.SearchMovie(ctx, "the matrix"); err != nil {
+		t.Fatal(err)
+	}
+	movie, _ := c.GetMovieDetails(ctx, 603)
+	season, _ := c.GetSeason(ctx, 1396, 1)
+	if backend.calls != 3 {
+		t.Fatalf("requêtes = %d, want 3", backend.calls)
+	}
+
+	// Même requête (casse et espaces près), même langue: servie par le cache, même après réouverture
+	c = open("fr-FR")
+	movies, err := c.SearchMovie(ctx, "  The   Matrix ")
+	if err != nil || len(movies) != 1 || movies[0].Year() != "1999" {
+		t.Fatalf("recherche en cache = %+v, %v", movies, err)
+	}
+	cachedMovie, _ := c.GetMovieDetails(ctx, 603)
+	cachedSeason, _ := c.GetSeason(ctx, 1396, 1)
+	if backend.calls != 3 {
+		t.Errorf("requêtes = %d, want 3 (cache)", backend.calls)
+	}
+	if cachedMovie.Runtime != movie.Runtime || len(cachedMovie.Genres) != 1 || cachedSeason.Episodes[0].Code() != season.Episodes[0].Code() {
+		t.Errorf("entrées en cache = %+v, %+v", cachedMovie, cachedSeason)
+	}
+
+	// Autre langue ou autre ID: nouvelles requêtes
+	open("en-US").SearchMovie(ctx, "the matrix")
+	c.GetMovieDetails(ctx, 604)
+	if backend.calls != 5 {
+		t.Errorf("requêtes = %d, want 5", backend.calls)
+	}
+
+	// Une recherche sans résultat n'est pas mise en cache
+	c.SearchTV(ctx, "inconnu")
+	c.SearchTV(ctx, "inconnu")
+	if backend.calls != 7 {
+		t.Errorf("requêtes = %d, want 7: une recherche vide ne doit pas être conservée", backend.calls)
+	}
+
+	// --refresh: TMDB est interrogé même si l'entrée est valide
+	c.SetRefresh(true)
+	c.GetMovieDetails(ctx, 603)
+	c.SetRefresh(false)
+	if backend.calls != 8 {
+		t.Errorf("requêtes = %d, want 8 (refresh)", backend.calls)
+	}
+
+	// Entrée expirée: nouvelle requête, mais secours sur l'entrée expirée si TMDB échoue
+	now = now.Add(2 * time.Hour)
+	backend.err = &StatusError{StatusCode: 429, Status: "429 Too Many Requests"}
+	movie, err = c.GetMovieDetails(ctx, 603)
+	if err != nil || movie.Runtime != 136 {
+		t.Errorf("secours = %+v, %v", movie, err)
+	}
+	if backend.calls != 9 {
+		t.Errorf("requêtes = %d, want 9 (entrée expirée)", backend.calls)
+	}
+	if _, err := c.GetMovieDetails(ctx, 1); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("sans entrée en cache, l'erreur de TMDB doit être retournée: %v", err)
+	}
+
+	removed, err := ClearCache(dir)
+	if err != nil || removed != 5 {
+		t.Errorf("ClearCache = %d, %v, want 5", removed, err)
+	}
+	backend.err = nil
+	c.SearchMovie(ctx, "the matrix")
+	if backend.calls != 11 {
+		t.Errorf("requêtes = %d, want 11 (cache vidé)", backend.calls)
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/PuerkitoBio/goquery"
 )
@@ -17,7 +16,7 @@ const (
 	baseURL = "https://www.themoviedb.org"
 )
 
-// Client représente un client pour le scraping TMDB
+// Client représente un client pour le scraping TMDB (backend scraper)
 type Client struct {
 	httpClient *http.Client
 	language   string
@@ -27,11 +26,9 @@ type Client struct {
 // NewClient crée un nouveau client TMDB (scraping)
 func NewClient() *Client {
 	return &Client{
-		httpClient: &http.Client{
-			Timeout: 15 * time.Second,
-		},
-		language:  "fr-FR",
-		userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+		httpClient: newHTTPClient(Config{}),
+		language:   DefaultLanguage,
+		userAgent:  "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
 	}
 }
 
@@ -40,6 +37,11 @@ func (c *Client) SetLanguage(lang string) {
 	c.language = lang
 }
 
+// Name implémente Provider
+func (c *Client) Name() string {
+	return BackendScraper
+}
+
 // doRequest effectue une requête HTTP avec les headers appropriés
 func (c *Client) doRequest(ctx context.Context, urlStr string) (*http.Response, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlStr, nil)
@@ -54,25 +56,51 @@ func (c *Client) doRequest(ctx context.Context, urlStr string) (*http.Response,
 	return c.httpClient.Do(req)
 }
 
-// SearchMovie recherche des films par mots-clés via scraping
-func (c *Client) SearchMovie(ctx context.Context, query string) ([]Movie, error) {
-	searchURL := fmt.Sprintf("%s/search/movie?query=%s&language=%s",
-		baseURL, url.QueryEscape(query), c.language)
+// englishTranslation retourne le titre anglais d'une fiche (path: /movie/603, /tv/1396).
+// Les pages de traductions n'étant pas exploitables, le titre est lu sur la page en-US;
+// un échec est ignoré, le titre anglais n'étant qu'une alternative.
+func (c *Client) englishTranslation(ctx context.Context, path, title string) []Translation {
+	if lang, _, _ := strings.Cut(c.language, "-"); !strings.EqualFold(lang, "en") {
+		doc, err := c.fetchDocument(ctx, baseURL+path+"?language=en-US")
+		if err != nil {
+			return nil
+		}
+		title = cleanText(doc.Find("section.header h2 a").First().Text())
+	}
+	if title == "" {
+		return nil
+	}
+	return []Translation{{Language: "en", Country: "US", Title: title}}
+}
 
-	resp, err := c.doRequest(ctx, searchURL)
+// fetchDocument télécharge et parse une page HTML de TMDB
+func (c *Client) fetchDocument(ctx context.Context, urlStr string) (*goquery.Document, error) {
+	resp, err := c.doRequest(ctx, urlStr)
 	if err != nil {
-		return nil, fmt.Errorf("erreur requête TMDB: %w", err)
+		return nil, requestError(ctx, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("TMDB erreur: %s", resp.Status)
+		return nil, statusError(resp, "")
 	}
 
 	doc, err := goquery.NewDocumentFromReader(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("erreur parsing HTML: %w", err)
 	}
+	return doc, nil
+}
+
+// SearchMovie recherche des films par mots-clés via scraping
+func (c *Client) SearchMovie(ctx context.Context, query string) ([]Movie, error) {
+	searchURL := fmt.Sprintf("%s/search/movie?query=%s&language=%s",
+		baseURL, url.QueryEscape(query), c.language)
+
+	doc, err := c.fetchDocument(ctx, searchURL)
+	if err != nil {
+		return nil, err
+	}
 
 	var movies []Movie
 
@@ -126,19 +154,9 @@ func (c *Client) SearchMovie(ctx context.Context, query string) ([]Movie, error)
 func (c *Client) GetMovieDetails(ctx context.Context, id int) (*Movie, error) {
 	movieURL := fmt.Sprintf("%s/movie/%d?language=%s", baseURL, id, c.language)
 
-	resp, err := c.doRequest(ctx, movieURL)
-	if err != nil {
-		return nil, fmt.Errorf("erreur requête TMDB: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("TMDB erreur: %s", resp.Status)
-	}
-
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	doc, err := c.fetchDocument(ctx, movieURL)
 	if err != nil {
-		return nil, fmt.Errorf("erreur parsing HTML: %w", err)
+		return nil, err
 	}
 
 	movie := &Movie{
@@ -149,22 +167,15 @@ func (c *Client) GetMovieDetails(ctx context.Context, id int) (*Movie, error) {
 	movie.Title = cleanText(doc.Find("section.header h2 a").First().Text())
 
 	// Titre original (chercher dans section.facts.left_column)
-	doc.Find("section.facts.left_column p").Each(func(i int, s *goquery.Selection) {
-		strong := cleanText(s.Find("strong").Text())
-		if strings.Contains(strings.ToLower(strong), "langue") && strings.Contains(strings.ToLower(strong), "origine") {
-			// C'est juste la langue, pas le titre original
-			return
-		}
-		if strings.Contains(strings.ToLower(strong), "titre") && strings.Contains(strings.ToLower(strong), "origin") {
-			fullText := cleanText(s.Text())
-			movie.OriginalTitle = strings.TrimSpace(strings.TrimPrefix(fullText, strong))
-		}
-	})
+	movie.OriginalTitle = parseOriginalTitle(doc)
 	// Si pas trouvé, utiliser le titre principal
 	if movie.OriginalTitle == "" {
 		movie.OriginalTitle = movie.Title
 	}
 
+	// Titre anglais (page en-US), pour les noms de release
+	movie.Translations = c.englishTranslation(ctx, fmt.Sprintf("/movie/%d", id), movie.Title)
+
 	// Tagline (dans div.header_info h3.tagline)
 	movie.Tagline = cleanText(doc.Find("div.header_info h3.tagline").Text())
 
@@ -194,20 +205,10 @@ func (c *Client) GetMovieDetails(ctx context.Context, id int) (*Movie, error) {
 	})
 
 	// Note (score utilisateur en pourcentage, convertir en note sur 10)
-	doc.Find("div.user_score_chart").Each(func(i int, s *goquery.Selection) {
-		if percent, exists := s.Attr("data-percent"); exists {
-			if val, err := strconv.ParseFloat(percent, 64); err == nil {
-				movie.VoteAverage = val / 10.0
-			}
-		}
-	})
+	movie.VoteAverage = parseScore(doc)
 
 	// Poster (dans div.poster div.image_content img.poster)
-	if img := doc.Find("div.poster div.image_content img.poster"); img.Length() > 0 {
-		if src, exists := img.Attr("src"); exists {
-			movie.PosterPath = extractPosterPath(src)
-		}
-	}
+	movie.PosterPath = parsePoster(doc)
 
 	// Backdrop (extrait du CSS background-image de div.header.large.first)
 	doc.Find("div.header.large.first").Each(func(i int, s *goquery.Selection) {
@@ -221,6 +222,68 @@ func (c *Client) GetMovieDetails(ctx context.Context, id int) (*Movie, error) {
 	})
 
 	// Cast (depuis la page principale - section.panel.top_billed ol.people li.card)
+	movie.Cast = parseCast(doc)
+
+	// Réalisateurs (depuis div.header_info ol.people.no_image li.profile)
+	doc.Find("div.header_info ol.people.no_image li.profile").Each(func(i int, s *goquery.Selection) {
+		job := cleanText(s.Find("p.character").Text())
+		if strings.Contains(strings.ToLower(job), "director") || strings.Contains(strings.ToLower(job), "réalisateur") {
+			name := cleanText(s.Find("p a").First().Text())
+			if name != "" {
+				movie.Directors = append(movie.Directors, name)
+			}
+		}
+	})
+
+	// IMDb ID - récupérer depuis les liens externes (section.facts.left_column a.social_link)
+	movie.IMDbID = parseIMDbID(doc)
+
+	return movie, nil
+}
+
+// parseOriginalTitle extrait le titre original des faits de la page (vide si absent)
+func parseOriginalTitle(doc *goquery.Document) string {
+	var title string
+	doc.Find("section.facts.left_column p").Each(func(i int, s *goquery.Selection) {
+		strong := cleanText(s.Find("strong").Text())
+		if strings.Contains(strings.ToLower(strong), "langue") && strings.Contains(strings.ToLower(strong), "origine") {
+			// C'est juste la langue, pas le titre original
+			return
+		}
+		if strings.Contains(strings.ToLower(strong), "titre") && strings.Contains(strings.ToLower(strong), "origin") {
+			fullText := cleanText(s.Text())
+			title = strings.TrimSpace(strings.TrimPrefix(fullText, strong))
+		}
+	})
+	return title
+}
+
+// parseScore extrait le score utilisateur (pourcentage) converti en note sur 10
+func parseScore(doc *goquery.Document) float64 {
+	var score float64
+	doc.Find("div.user_score_chart").Each(func(i int, s *goquery.Selection) {
+		if percent, exists := s.Attr("data-percent"); exists {
+			if val, err := strconv.ParseFloat(percent, 64); err == nil {
+				score = val / 10.0
+			}
+		}
+	})
+	return score
+}
+
+// parsePoster extrait le chemin du poster de la page
+func parsePoster(doc *goquery.Document) string {
+	if img := doc.Find("div.poster div.image_content img.poster"); img.Length() > 0 {
+		if src, exists := img.Attr("src"); exists {
+			return extractPosterPath(src)
+		}
+	}
+	return ""
+}
+
+// parseCast extrait les 10 premiers rôles de la page (section.panel.top_billed)
+func parseCast(doc *goquery.Document) []CastMember {
+	var cast []CastMember
 	doc.Find("section.panel.top_billed ol.people li.card").Each(func(i int, s *goquery.Selection) {
 		if i >= 10 {
 			return
@@ -237,7 +300,7 @@ func (c *Client) GetMovieDetails(ctx context.Context, id int) (*Movie, error) {
 		}
 
 		if name != "" {
-			movie.Cast = append(movie.Cast, CastMember{
+			cast = append(cast, CastMember{
 				Name:        name,
 				Character:   character,
 				Order:       i,
@@ -245,38 +308,30 @@ func (c *Client) GetMovieDetails(ctx context.Context, id int) (*Movie, error) {
 			})
 		}
 	})
+	return cast
+}
 
-	// Réalisateurs (depuis div.header_info ol.people.no_image li.profile)
-	doc.Find("div.header_info ol.people.no_image li.profile").Each(func(i int, s *goquery.Selection) {
-		job := cleanText(s.Find("p.character").Text())
-		if strings.Contains(strings.ToLower(job), "director") || strings.Contains(strings.ToLower(job), "réalisateur") {
-			name := cleanText(s.Find("p a").First().Text())
-			if name != "" {
-				movie.Directors = append(movie.Directors, name)
-			}
-		}
-	})
-
-	// IMDb ID - récupérer depuis les liens externes (section.facts.left_column a.social_link)
+// parseIMDbID extrait l'identifiant IMDb des liens externes de la page
+func parseIMDbID(doc *goquery.Document) string {
+	var id string
 	doc.Find("section.facts.left_column a.social_link").Each(func(i int, s *goquery.Selection) {
 		if href, exists := s.Attr("href"); exists {
 			if strings.Contains(href, "imdb.com") {
 				// Extraire l'ID IMDb
 				re := regexp.MustCompile(`(tt\d+)`)
 				if match := re.FindString(href); match != "" {
-					movie.IMDbID = match
+					id = match
 				}
 			}
 		}
 	})
-
-	return movie, nil
+	return id
 }
 
 // extractIDFromURL extrait l'ID depuis une URL TMDB
 func extractIDFromURL(urlPath string) int {
-	// Format: /movie/12345-slug ou /movie/12345
-	re := regexp.MustCompile(`/movie/(\d+)`)
+	// Format: /movie/12345-slug, /movie/12345 ou /tv/1399-slug
+	re := regexp.MustCompile(`/(?:movie|tv)/(\d+)`)
 	matches := re.FindStringSubmatch(urlPath)
 	if len(matches) >= 2 {
 		id, _ := strconv.Atoi(matches[1])
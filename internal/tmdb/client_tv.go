@@ -0,0 +1,176 @@
+package tmdb
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// SearchTV recherche des séries par mots-clés via scraping
+func (c *Client) SearchTV(ctx context.Context, query string) ([]Show, error) {
+	searchURL := fmt.Sprintf("%s/search/tv?query=%s&language=%s",
+		baseURL, url.QueryEscape(query), c.language)
+
+	doc, err := c.fetchDocument(ctx, searchURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var shows []Show
+
+	// Même structure que la recherche de films, dans div.search_results.tv
+	doc.Find("div.search_results.tv div.card").Each(func(i int, s *goquery.Selection) {
+		show := Show{}
+
+		if href, exists := s.Find("a.result").Attr("href"); exists {
+			// Format: /tv/1399-slug
+			show.ID = extractIDFromURL(href)
+		}
+
+		show.Name = cleanText(s.Find("h2").First().Text())
+		if origName := s.Find("h2 span.title").Text(); origName != "" {
+			origName = strings.TrimPrefix(origName, "(")
+			origName = strings.TrimSuffix(origName, ")")
+			show.OriginalName = cleanText(origName)
+		}
+		if show.OriginalName == "" {
+			show.OriginalName = show.Name
+		}
+
+		show.FirstAirDate = cleanText(s.Find("span.release_date").Text())
+		show.Overview = cleanText(s.Find("div.overview p").Text())
+		if src, exists := s.Find("img.poster").Attr("src"); exists {
+			show.PosterPath = extractPosterPath(src)
+		}
+
+		if show.ID > 0 && show.Name != "" {
+			shows = append(shows, show)
+		}
+	})
+
+	return shows, nil
+}
+
+// GetTVDetails récupère les détails complets d'une série via scraping
+func (c *Client) GetTVDetails(ctx context.Context, id int) (*Show, error) {
+	showURL := fmt.Sprintf("%s/tv/%d?language=%s", baseURL, id, c.language)
+
+	doc, err := c.fetchDocument(ctx, showURL)
+	if err != nil {
+		return nil, err
+	}
+
+	show := &Show{
+		ID: id,
+	}
+
+	// Nom (section.header h2 a) et année de première diffusion (h2 span.release_date: "(2008)")
+	show.Name = cleanText(doc.Find("section.header h2 a").First().Text())
+	show.FirstAirDate = strings.Trim(cleanText(doc.Find("section.header h2 span.release_date").Text()), "()")
+	show.Translations = c.englishTranslation(ctx, fmt.Sprintf("/tv/%d", id), show.Name)
+
+	show.OriginalName = parseOriginalTitle(doc)
+	if show.OriginalName == "" {
+		show.OriginalName = show.Name
+	}
+
+	show.Tagline = cleanText(doc.Find("div.header_info h3.tagline").Text())
+	show.Overview = cleanText(doc.Find("div.header_info div.overview p").Text())
+	show.EpisodeRuntime = parseRuntime(cleanText(doc.Find("div.title div.facts span.runtime").Text()))
+
+	doc.Find("div.title div.facts span.genres a").Each(func(i int, s *goquery.Selection) {
+		if genre := cleanText(s.Text()); genre != "" {
+			show.Genres = append(show.Genres, genre)
+		}
+	})
+
+	show.VoteAverage = parseScore(doc)
+	show.PosterPath = parsePoster(doc)
+	show.Cast = parseCast(doc)
+	show.IMDbID = parseIMDbID(doc)
+
+	// Créateurs (div.header_info ol.people.no_image li.profile, rôle "Creator" / "Création")
+	doc.Find("div.header_info ol.people.no_image li.profile").Each(func(i int, s *goquery.Selection) {
+		job := strings.ToLower(cleanText(s.Find("p.character").Text()))
+		if strings.Contains(job, "creat") || strings.Contains(job, "créat") {
+			if name := cleanText(s.Find("p a").First().Text()); name != "" {
+				show.Creators = append(show.Creators, name)
+			}
+		}
+	})
+
+	// Chaînes (logos dans section.facts.left_column ul.networks, nom dans l'attribut alt)
+	doc.Find("section.facts.left_column ul.networks li img").Each(func(i int, s *goquery.Selection) {
+		if name, exists := s.Attr("alt"); exists && name != "" {
+			show.Networks = append(show.Networks, cleanText(name))
+		}
+	})
+
+	// Nombre de saisons (liens /tv/{id}/season/{n} de la dernière saison)
+	doc.Find("section.panel.season div.season_wrapper h2 a").Each(func(i int, s *goquery.Selection) {
+		if href, exists := s.Attr("href"); exists {
+			if n := seasonFromURL(href); n > show.NumberOfSeasons {
+				show.NumberOfSeasons = n
+			}
+		}
+	})
+
+	return show, nil
+}
+
+// GetSeason récupère une saison et ses épisodes via scraping
+func (c *Client) GetSeason(ctx context.Context, showID, season int) (*Season, error) {
+	seasonURL := fmt.Sprintf("%s/tv/%d/season/%d?language=%s", baseURL, showID, season, c.language)
+
+	doc, err := c.fetchDocument(ctx, seasonURL)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Season{
+		ShowID:       showID,
+		SeasonNumber: season,
+		Name:         cleanText(doc.Find("section.header h2 a").First().Text()),
+		Overview:     cleanText(doc.Find("div.header_info div.overview p").Text()),
+		PosterPath:   parsePoster(doc),
+	}
+
+	// Épisodes (div.episode_list div.card, numéro dans span.episode_number)
+	doc.Find("div.episode_list div.card").Each(func(i int, s *goquery.Selection) {
+		number, err := strconv.Atoi(cleanText(s.Find("span.episode_number").First().Text()))
+		if err != nil {
+			return
+		}
+		episode := Episode{
+			SeasonNumber:  season,
+			EpisodeNumber: number,
+			Name:          cleanText(s.Find("div.episode_title h3 a").First().Text()),
+			Overview:      cleanText(s.Find("div.overview p").First().Text()),
+			AirDate:       cleanText(s.Find("div.date span.date").First().Text()),
+			Runtime:       parseRuntime(cleanText(s.Find("div.date span.runtime").First().Text())),
+		}
+		if src, exists := s.Find("img.backdrop").Attr("src"); exists {
+			episode.StillPath = extractPosterPath(src)
+		}
+		result.Episodes = append(result.Episodes, episode)
+	})
+
+	if len(result.Episodes) > 0 {
+		result.AirDate = result.Episodes[0].AirDate
+	}
+	return result, nil
+}
+
+// seasonFromURL extrait le numéro de saison d'une URL /tv/{id}/season/{n}
+func seasonFromURL(urlPath string) int {
+	_, after, found := strings.Cut(urlPath, "/season/")
+	if !found {
+		return 0
+	}
+	n, _ := strconv.Atoi(strings.SplitN(after, "?", 2)[0])
+	return n
+}
@@ -0,0 +1,101 @@
+package tmdb
+
+import (
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// EpisodeRef décrit la saison et les épisodes détectés dans un nom de fichier ou de dossier
+type EpisodeRef struct {
+	Keywords string // mots-clés de recherche de la série (texte qui précède SxxEyy)
+	Season   int
+	Episodes []int // vide pour un pack de saison
+}
+
+// IsSeasonPack indique si le nom désigne une saison complète plutôt que des épisodes
+func (r EpisodeRef) IsSeasonPack() bool {
+	return len(r.Episodes) == 0
+}
+
+// Code retourne le code de la référence: S01E02, S01E02E03 ou S01 pour un pack
+func (r EpisodeRef) Code() string {
+	return EpisodeCode(r.Season, r.Episodes...)
+}
+
+// Les séparateurs des noms de release: un motif n'est reconnu qu'entre deux séparateurs
+const (
+	sepBefore = `(?:^|[ ._\-\[(])`
+	sepAfter  = `(?:$|[ ._\-\])])`
+)
+
+var (
+	// S01E02, S01E02E03, S01E02-E04, s1e2
+	episodeRe     = regexp.MustCompile(`(?i)` + sepBefore + `S(\d{1,2})[ ._]?E(\d{1,3})((?:-?E\d{1,3})*)` + sepAfter)
+	episodeTailRe = regexp.MustCompile(`(?i)(-?)E(\d{1,3})`)
+	// 1x02
+	crossRe = regexp.MustCompile(`(?i)` + sepBefore + `(\d{1,2})x(\d{2,3})` + sepAfter)
+	// S01, Season 1, Saison 01
+	seasonRe = regexp.MustCompile(`(?i)` + sepBefore + `(?:S|Season[ ._]?|Saison[ ._]?)(\d{1,2})` + sepAfter)
+
+	videoExtensions = map[string]bool{
+		".mkv": true, ".mp4": true, ".m4v": true, ".avi": true,
+		".ts": true, ".m2ts": true, ".wmv": true, ".mov": true,
+	}
+)
+
+// ParseEpisode détecte un épisode (S01E02, S01E02E03, 1x02) ou un pack de saison (S01, Season 1)
+// dans un nom de fichier ou de dossier. Retourne false pour un nom de film.
+func ParseEpisode(name string) (EpisodeRef, bool) {
+	if IsVideoFile(name) {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+
+	if m := episodeRe.FindStringSubmatchIndex(name); m != nil {
+		season, _ := strconv.Atoi(name[m[2]:m[3]])
+		first, _ := strconv.Atoi(name[m[4]:m[5]])
+		episodes := []int{first}
+		for _, tail := range episodeTailRe.FindAllStringSubmatch(name[m[6]:m[7]], -1) {
+			n, _ := strconv.Atoi(tail[2])
+			last := episodes[len(episodes)-1]
+			if tail[1] == "-" {
+				// Intervalle: S01E02-E04 couvre les épisodes 2, 3 et 4
+				for e := last + 1; e < n; e++ {
+					episodes = append(episodes, e)
+				}
+			}
+			if n > last {
+				episodes = append(episodes, n)
+			}
+		}
+		return EpisodeRef{Keywords: showKeywords(name[:m[0]]), Season: season, Episodes: episodes}, true
+	}
+
+	if m := crossRe.FindStringSubmatchIndex(name); m != nil {
+		season, _ := strconv.Atoi(name[m[2]:m[3]])
+		episode, _ := strconv.Atoi(name[m[4]:m[5]])
+		return EpisodeRef{Keywords: showKeywords(name[:m[0]]), Season: season, Episodes: []int{episode}}, true
+	}
+
+	if m := seasonRe.FindStringSubmatchIndex(name); m != nil {
+		season, _ := strconv.Atoi(name[m[2]:m[3]])
+		return EpisodeRef{Keywords: showKeywords(name[:m[0]]), Season: season}, true
+	}
+
+	return EpisodeRef{}, false
+}
+
+// showKeywords nettoie le titre d'une série extrait d'un nom de release
+func showKeywords(title string) string {
+	title = regexp.MustCompile(`\[(.*?)\]|\{(.*?)\}`).ReplaceAllString(title, " ")
+	title = regexp.MustCompile(`[._\-()]`).ReplaceAllString(title, " ")
+	// L'année sert à distinguer les remakes sur le nom de release, pas à la recherche
+	title = regexp.MustCompile(`\b(19|20)\d{2}\b`).ReplaceAllString(title, " ")
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
+
+// IsVideoFile indique si name a une extension de fichier vidéo
+func IsVideoFile(name string) bool {
+	return videoExtensions[strings.ToLower(filepath.Ext(name))]
+}
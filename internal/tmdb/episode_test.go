@@ -0,0 +1,49 @@
+package tmdb
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseEpisode(t *testing.T) {
+	tests := []struct {
+		name string
+		want EpisodeRef
+		ok   bool
+	}{
+		{"Breaking.Bad.S01E02.1080p.WEB.H264-GRP.mkv", EpisodeRef{Keywords: "breaking bad", Season: 1, Episodes: []int{2}}, true},
+		{"breaking bad s01e02.mkv", EpisodeRef{Keywords: "breaking bad", Season: 1, Episodes: []int{2}}, true},
+		{"The.Office.US.S03E01E02.720p.mkv", EpisodeRef{Keywords: "the office us", Season: 3, Episodes: []int{1, 2}}, true},
+		{"Show.S02E03-E05.mkv", EpisodeRef{Keywords: "show", Season: 2, Episodes: []int{3, 4, 5}}, true},
+		{"Doctor.Who.2005.S10E01.mkv", EpisodeRef{Keywords: "doctor who", Season: 10, Episodes: []int{1}}, true},
+		{"Friends - 1x05 - The One.avi", EpisodeRef{Keywords: "friends", Season: 1, Episodes: []int{5}}, true},
+		{"Show.S01.COMPLETE.1080p.WEB.H264-GRP", EpisodeRef{Keywords: "show", Season: 1}, true},
+		{"Show Season 2", EpisodeRef{Keywords: "show", Season: 2}, true},
+		{"Show.Saison.03.FRENCH.WEB", EpisodeRef{Keywords: "show", Season: 3}, true},
+		{"The.Matrix.1999.1080p.BluRay.x264-GRP.mkv", EpisodeRef{}, false},
+		{"Se7en.1995.mkv", EpisodeRef{}, false},
+		{"1917.2019.2160p.mkv", EpisodeRef{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseEpisode(tt.name)
+			if ok != tt.ok || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseEpisode() = %+v, %v, want %+v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestEpisodeCode(t *testing.T) {
+	for want, got := range map[string]string{
+		"S01E02":    EpisodeCode(1, 2),
+		"S01E02E03": EpisodeCode(1, 2, 3),
+		"S12":       EpisodeCode(12),
+		"S01E102":   (&Episode{SeasonNumber: 1, EpisodeNumber: 102}).Code(),
+	} {
+		if got != want {
+			t.Errorf("code = %s, want %s", got, want)
+		}
+	}
+}
@@ -22,6 +22,9 @@ type Movie struct {
 	ProductionCompanies []string     `json:"production_companies"`
 	Directors           []string     `json:"directors"`
 	Cast                []CastMember `json:"cast"`
+
+	Translations      []Translation      `json:"translations"`
+	AlternativeTitles []AlternativeTitle `json:"alternative_titles"`
 }
 
 // CastMember représente un membre du casting
@@ -34,19 +37,24 @@ type CastMember struct {
 
 // Year retourne l'année de sortie du film
 func (m *Movie) Year() string {
-	if m.ReleaseDate == "" {
+	return yearOf(m.ReleaseDate)
+}
+
+// yearOf extrait l'année d'une date TMDB
+func yearOf(date string) string {
+	if date == "" {
 		return ""
 	}
 
 	// Format API: "2009-08-19" ou "2009"
-	if len(m.ReleaseDate) >= 4 && m.ReleaseDate[4] == '-' {
-		return m.ReleaseDate[:4]
+	if len(date) > 4 && date[4] == '-' {
+		return date[:4]
 	}
 
 	// Format scraping français: "19/08/2009 (FR)" ou "27/11/2024 (FR)"
 	// Chercher une année à 4 chiffres dans la chaîne
-	for i := 0; i <= len(m.ReleaseDate)-4; i++ {
-		candidate := m.ReleaseDate[i : i+4]
+	for i := 0; i <= len(date)-4; i++ {
+		candidate := date[i : i+4]
 		// Vérifier que ce sont 4 chiffres commençant par 1 ou 2
 		if len(candidate) == 4 && (candidate[0] == '1' || candidate[0] == '2') {
 			isYear := true
@@ -63,8 +71,8 @@ func (m *Movie) Year() string {
 	}
 
 	// Fallback: si au moins 4 caractères, prendre les 4 premiers
-	if len(m.ReleaseDate) >= 4 {
-		return m.ReleaseDate[:4]
+	if len(date) >= 4 {
+		return date[:4]
 	}
 
 	return ""
@@ -78,7 +86,7 @@ func (m *Movie) PosterURL(size string) string {
 	if size == "" {
 		size = "w500"
 	}
-	return "https://image.tmdb.org/t/p/" + size + m.PosterPath
+	return imageURL(size, m.PosterPath)
 }
 
 // BackdropURL retourne l'URL complète du backdrop
@@ -89,7 +97,7 @@ func (m *Movie) BackdropURL(size string) string {
 	if size == "" {
 		size = "w1280"
 	}
-	return "https://image.tmdb.org/t/p/" + size + m.BackdropPath
+	return imageURL(size, m.BackdropPath)
 }
 
 // IMDbURL retourne l'URL IMDb du film
@@ -97,10 +105,20 @@ func (m *Movie) IMDbURL() string {
 	if m.IMDbID == "" {
 		return ""
 	}
-	return "https://www.imdb.com/title/" + m.IMDbID
+	return imdbURL(m.IMDbID)
 }
 
 // TMDbURL retourne l'URL TMDB du film
 func (m *Movie) TMDbURL() string {
 	return fmt.Sprintf("https://www.themoviedb.org/movie/%d", m.ID)
 }
+
+// imageURL retourne l'URL complète d'une image TMDB
+func imageURL(size, path string) string {
+	return "https://image.tmdb.org/t/p/" + size + path
+}
+
+// imdbURL retourne l'URL IMDb d'un identifiant (film ou série)
+func imdbURL(id string) string {
+	return "https://www.imdb.com/title/" + id
+}
@@ -50,3 +50,16 @@ func TestYear(t *testing.T) {
 		})
 	}
 }
+
+// TestYearShortDate couvre les dates de 4 caractères ou moins: date[4] était lu sans vérifier
+// la longueur (len >= 4) et une année seule ("2009") faisait paniquer Year()
+func TestYearShortDate(t *testing.T) {
+	for date, want := range map[string]string{"2009": "2009", "200": "", "9": "", "2009-": "2009"} {
+		if got := (&Movie{ReleaseDate: date}).Year(); got != want {
+			t.Errorf("Movie.Year(%q) = %q, want %q", date, got, want)
+		}
+		if got := (&Show{FirstAirDate: date}).Year(); got != want {
+			t.Errorf("Show.Year(%q) = %q, want %q", date, got, want)
+		}
+	}
+}
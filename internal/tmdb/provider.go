@@ -0,0 +1,71 @@
+package tmdb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Backends de métadonnées disponibles
+const (
+	BackendScraper = "scraper" // scraping des pages web de themoviedb.org, sans clé
+	BackendAPI     = "api"     // API officielle TMDB v3 (JSON), avec clé d'API ou jeton
+)
+
+// DefaultLanguage est la langue des métadonnées par défaut
+const DefaultLanguage = "fr-FR"
+
+// Provider est une source de métadonnées de films et de séries
+type Provider interface {
+	// Name retourne le nom du backend (scraper, api)
+	Name() string
+	// SearchMovie recherche des films par mots-clés
+	SearchMovie(ctx context.Context, query string) ([]Movie, error)
+	// GetMovieDetails récupère les détails complets d'un film
+	GetMovieDetails(ctx context.Context, id int) (*Movie, error)
+	// SearchTV recherche des séries par mots-clés
+	SearchTV(ctx context.Context, query string) ([]Show, error)
+	// GetTVDetails récupère les détails complets d'une série
+	GetTVDetails(ctx context.Context, id int) (*Show, error)
+	// GetSeason récupère une saison d'une série et la liste de ses épisodes
+	GetSeason(ctx context.Context, showID, season int) (*Season, error)
+}
+
+// Config décrit la source de métadonnées (section tmdb de la config)
+type Config struct {
+	Backend  string        `mapstructure:"backend"`  // scraper ou api (défaut: api si une clé est fournie)
+	APIKey   string        `mapstructure:"api_key"`  // clé d'API v3 (paramètre api_key)
+	Token    string        `mapstructure:"token"`    // jeton d'accès en lecture (en-tête Authorization: Bearer)
+	Language string        `mapstructure:"language"` // défaut: fr-FR
+	URL      string        `mapstructure:"url"`      // URL de base de l'API (défaut: https://api.themoviedb.org)
+	Timeout  time.Duration `mapstructure:"timeout"`  // délai de chaque tentative (défaut: 15s)
+
+	MaxRetries int     `mapstructure:"max_retries"` // nouvelles tentatives sur 429, 5xx et erreur réseau (défaut: 3, -1 = aucune)
+	RateLimit  float64 `mapstructure:"rate_limit"`  // requêtes par seconde (défaut: 5, -1 = illimité)
+}
+
+// New crée le backend de métadonnées configuré
+func New(cfg Config) (Provider, error) {
+	backend := strings.ToLower(cfg.Backend)
+	if backend == "" {
+		backend = BackendScraper
+		if cfg.APIKey != "" || cfg.Token != "" {
+			backend = BackendAPI
+		}
+	}
+
+	switch backend {
+	case BackendScraper:
+		c := NewClient()
+		c.httpClient = newHTTPClient(cfg)
+		if cfg.Language != "" {
+			c.SetLanguage(cfg.Language)
+		}
+		return c, nil
+	case BackendAPI:
+		return newAPIClient(cfg)
+	default:
+		return nil, fmt.Errorf("backend TMDB inconnu: %q (scraper ou api)", cfg.Backend)
+	}
+}
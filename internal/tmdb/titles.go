@@ -0,0 +1,67 @@
+package tmdb
+
+import "strings"
+
+// Translation est le titre d'une traduction TMDB
+type Translation struct {
+	Language string `json:"language"` // code ISO 639-1 (en, fr)
+	Country  string `json:"country"`  // code ISO 3166-1 (US, FR)
+	Title    string `json:"title"`
+}
+
+// AlternativeTitle est un titre alternatif utilisé dans un pays
+type AlternativeTitle struct {
+	Country string `json:"country"` // code ISO 3166-1
+	Title   string `json:"title"`
+	Type    string `json:"type"` // précision de TMDB (romanisation, titre de travail...), vide en général
+}
+
+// languageCountries sont les pays dont les titres alternatifs tiennent lieu de traduction
+var languageCountries = map[string][]string{
+	"en": {"US", "GB"},
+	"fr": {"FR", "CA", "BE"},
+	"de": {"DE", "AT"},
+	"es": {"ES", "MX"},
+}
+
+// TitleIn retourne le titre du film dans une langue (en, en-US, fr-FR...), vide s'il est inconnu
+func (m *Movie) TitleIn(language string) string {
+	return titleIn(language, m.Translations, m.AlternativeTitles)
+}
+
+// NameIn retourne le nom de la série dans une langue (en, en-US, fr-FR...), vide s'il est inconnu
+func (s *Show) NameIn(language string) string {
+	return titleIn(language, s.Translations, s.AlternativeTitles)
+}
+
+// titleIn cherche un titre dans les traductions (pays exact, puis langue seule), puis dans les
+// titres alternatifs des pays de la langue
+func titleIn(language string, translations []Translation, alternatives []AlternativeTitle) string {
+	lang, country, _ := strings.Cut(language, "-")
+	lang = strings.ToLower(lang)
+	country = strings.ToUpper(country)
+
+	for _, exact := range []bool{true, false} {
+		for _, t := range translations {
+			if t.Title == "" || !strings.EqualFold(t.Language, lang) {
+				continue
+			}
+			if !exact || country == "" || strings.EqualFold(t.Country, country) {
+				return t.Title
+			}
+		}
+	}
+
+	countries := languageCountries[lang]
+	if country != "" {
+		countries = append([]string{country}, countries...)
+	}
+	for _, c := range countries {
+		for _, alt := range alternatives {
+			if alt.Title != "" && strings.EqualFold(alt.Country, c) {
+				return alt.Title
+			}
+		}
+	}
+	return ""
+}
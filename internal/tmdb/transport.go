@@ -0,0 +1,240 @@
+package tmdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Valeurs par défaut de la couche HTTP
+const (
+	defaultTimeout    = 15 * time.Second
+	defaultMaxRetries = 3
+	defaultRateLimit  = 5 // requêtes par seconde
+	retryBaseDelay    = 500 * time.Millisecond
+	retryMaxDelay     = 30 * time.Second
+)
+
+// Erreurs typées des backends, à tester avec errors.Is
+var (
+	// ErrNotFound indique que le film, la série ou la saison n'existe pas sur TMDB
+	ErrNotFound = errors.New("introuvable sur TMDB")
+	// ErrUnavailable indique une panne passagère (réseau, 429, 5xx) persistante après les nouvelles tentatives
+	ErrUnavailable = errors.New("TMDB temporairement indisponible")
+)
+
+// StatusError est une réponse HTTP en erreur de TMDB
+type StatusError struct {
+	StatusCode int
+	Status     string
+	Message    string        // status_message de l'API, vide pour le scraper
+	RetryAfter time.Duration // délai demandé par l'en-tête Retry-After (0 si absent)
+}
+
+func (e *StatusError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("TMDB erreur: %s: %s", e.Status, e.Message)
+	}
+	return fmt.Sprintf("TMDB erreur: %s", e.Status)
+}
+
+// Is rattache le statut HTTP aux erreurs ErrNotFound et ErrUnavailable
+func (e *StatusError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnavailable:
+		return retryableStatus(e.StatusCode)
+	}
+	return false
+}
+
+// statusError construit l'erreur d'une réponse non 200
+func statusError(resp *http.Response, message string) error {
+	return &StatusError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Message:    message,
+		RetryAfter: retryAfter(resp, time.Now()),
+	}
+}
+
+// requestError qualifie l'échec d'une requête: une annulation reste une annulation,
+// le reste (réseau, délai dépassé) est une indisponibilité passagère
+func requestError(ctx context.Context, err error) error {
+	// L'URL de la requête porte la clé d'API: elle ne doit pas apparaître dans les messages
+	if urlErr, ok := err.(*url.Error); ok {
+		redacted := *urlErr
+		redacted.URL = redactURL(urlErr.URL)
+		err = &redacted
+	}
+	if ctx.Err() != nil {
+		return fmt.Errorf("erreur requête TMDB: %w", err)
+	}
+	return fmt.Errorf("erreur requête TMDB: %w: %w", ErrUnavailable, err)
+}
+
+// redactURL masque la valeur du paramètre api_key d'une URL
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "<URL invalide>"
+	}
+	query := u.Query()
+	if !query.Has("api_key") {
+		return rawURL
+	}
+	query.Set("api_key", "REDACTED")
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// retryTransport rejoue les requêtes en échec passager avec un backoff exponentiel, respecte
+// Retry-After et limite le débit avec un seau à jetons partagé par toutes les requêtes du client
+type retryTransport struct {
+	base       http.RoundTripper
+	limiter    *rate.Limiter
+	timeout    time.Duration // délai de chaque tentative
+	maxRetries int
+	sleep      func(ctx context.Context, d time.Duration) error
+}
+
+// newHTTPClient crée le client HTTP d'un backend à partir de la configuration
+func newHTTPClient(cfg Config) *http.Client {
+	t := &retryTransport{
+		base:       http.DefaultTransport,
+		limiter:    rate.NewLimiter(defaultRateLimit, defaultRateLimit),
+		timeout:    defaultTimeout,
+		maxRetries: defaultMaxRetries,
+		sleep:      sleepContext,
+	}
+	if cfg.Timeout > 0 {
+		t.timeout = cfg.Timeout
+	}
+	switch {
+	case cfg.MaxRetries < 0:
+		t.maxRetries = 0
+	case cfg.MaxRetries > 0:
+		t.maxRetries = cfg.MaxRetries
+	}
+	switch {
+	case cfg.RateLimit < 0:
+		t.limiter = rate.NewLimiter(rate.Inf, 0)
+	case cfg.RateLimit > 0:
+		t.limiter = rate.NewLimiter(rate.Limit(cfg.RateLimit), max(int(cfg.RateLimit), 1))
+	}
+	return &http.Client{Transport: t}
+}
+
+// RoundTrip implémente http.RoundTripper
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		if err := t.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		resp, err := t.attempt(req)
+		if attempt >= t.maxRetries || ctx.Err() != nil {
+			return resp, err
+		}
+
+		delay := backoff(attempt)
+		if err == nil {
+			if !retryableStatus(resp.StatusCode) {
+				return resp, nil
+			}
+			if wait := retryAfter(resp, time.Now()); wait > 0 {
+				// Un délai trop long est laissé à l'appelant (StatusError.RetryAfter)
+				if wait > retryMaxDelay {
+					return resp, nil
+				}
+				delay = wait
+			}
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+
+		if err := t.sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// attempt envoie une tentative avec son propre délai, libéré à la fermeture du corps de la réponse
+func (t *retryTransport) attempt(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelBody libère le contexte d'une tentative une fois la réponse lue
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// retryableStatus indique si un statut HTTP signale une panne passagère
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff retourne le délai avant la tentative attempt+1: exponentiel, plafonné, avec une part aléatoire
+func backoff(attempt int) time.Duration {
+	delay := retryMaxDelay
+	if attempt < 16 && retryBaseDelay<<attempt < retryMaxDelay {
+		delay = retryBaseDelay << attempt
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// retryAfter lit l'en-tête Retry-After (secondes ou date HTTP), 0 si absent ou invalide
+func retryAfter(resp *http.Response, now time.Time) time.Duration {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// sleepContext attend d ou l'annulation de ctx
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
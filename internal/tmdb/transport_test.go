@@ -0,0 +1,184 @@
+package tmdb
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newFlakyAPI démarre un faux serveur qui répond status aux failures premières requêtes, puis un film
+func newFlakyAPI(t *testing.T, failures int32, status int, header http.Header) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			io.WriteString(w, `{"status_code": 25, "status_message": "Your request count is over the allowed limit."}`)
+			return
+		}
+		io.WriteString(w, `{"id": 603, "title": "Matrix", "original_title": "The Matrix"}`)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// recordSleeps remplace les attentes du client par un enregistrement des délais
+func recordSleeps(c *APIClient) *[]time.Duration {
+	var delays []time.Duration
+	c.httpClient.Transport.(*retryTransport).sleep = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	return &delays
+}
+
+func TestRetryTransport(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("5xx puis succès", func(t *testing.T) {
+		server, requests := newFlakyAPI(t, 2, http.StatusServiceUnavailable, nil)
+		c, _ := newAPIClient(Config{APIKey: "KEY", URL: server.URL})
+		delays := recordSleeps(c)
+
+		movie, err := c.GetMovieDetails(ctx, 603)
+		if err != nil || movie.ID != 603 {
+			t.Fatalf("film = %+v, %v", movie, err)
+		}
+		if requests.Load() != 3 || len(*delays) != 2 {
+			t.Fatalf("requêtes = %d, attentes = %v", requests.Load(), *delays)
+		}
+		// Backoff exponentiel: [250ms, 500ms] puis [500ms, 1s]
+		if d := (*delays)[0]; d < retryBaseDelay/2 || d > retryBaseDelay {
+			t.Errorf("premier délai = %v", d)
+		}
+		if d := (*delays)[1]; d < retryBaseDelay || d > 2*retryBaseDelay {
+			t.Errorf("second délai = %v", d)
+		}
+	})
+
+	t.Run("Retry-After", func(t *testing.T) {
+		server, _ := newFlakyAPI(t, 1, http.StatusTooManyRequests, http.Header{"Retry-After": {"2"}})
+		c, _ := newAPIClient(Config{APIKey: "KEY", URL: server.URL})
+		delays := recordSleeps(c)
+
+		if _, err := c.GetMovieDetails(ctx, 603); err != nil {
+			t.Fatal(err)
+		}
+		if len(*delays) != 1 || (*delays)[0] != 2*time.Second {
+			t.Errorf("attentes = %v, want [2s]", *delays)
+		}
+	})
+
+	t.Run("indisponible", func(t *testing.T) {
+		server, requests := newFlakyAPI(t, 100, http.StatusTooManyRequests, http.Header{"Retry-After": {"120"}})
+		c, _ := newAPIClient(Config{APIKey: "KEY", URL: server.URL, MaxRetries: 2})
+		recordSleeps(c)
+
+		// Retry-After au-delà du délai maximal: pas d'attente, l'erreur porte le délai demandé
+		_, err := c.GetMovieDetails(ctx, 603)
+		var statusErr *StatusError
+		if !errors.Is(err, ErrUnavailable) || errors.Is(err, ErrNotFound) || !errors.As(err, &statusErr) {
+			t.Fatalf("erreur = %v, want ErrUnavailable", err)
+		}
+		if statusErr.RetryAfter != 2*time.Minute || requests.Load() != 1 {
+			t.Errorf("RetryAfter = %v, requêtes = %d", statusErr.RetryAfter, requests.Load())
+		}
+
+		server, requests = newFlakyAPI(t, 100, http.StatusBadGateway, nil)
+		c, _ = newAPIClient(Config{APIKey: "KEY", URL: server.URL, MaxRetries: 2})
+		recordSleeps(c)
+		if _, err := c.GetMovieDetails(ctx, 603); !errors.Is(err, ErrUnavailable) {
+			t.Errorf("erreur = %v, want ErrUnavailable", err)
+		}
+		if requests.Load() != 3 {
+			t.Errorf("requêtes = %d, want 3 (1 + 2 nouvelles tentatives)", requests.Load())
+		}
+	})
+
+	t.Run("introuvable", func(t *testing.T) {
+		server, requests := newFlakyAPI(t, 100, http.StatusNotFound, nil)
+		c, _ := newAPIClient(Config{APIKey: "KEY", URL: server.URL})
+		recordSleeps(c)
+
+		if _, err := c.GetMovieDetails(ctx, 603); !errors.Is(err, ErrNotFound) || errors.Is(err, ErrUnavailable) {
+			t.Errorf("erreur = %v, want ErrNotFound", err)
+		}
+		if requests.Load() != 1 {
+			t.Errorf("requêtes = %d, une 404 ne doit pas être rejouée", requests.Load())
+		}
+	})
+
+	t.Run("réseau", func(t *testing.T) {
+		server, _ := newFlakyAPI(t, 0, 0, nil)
+		server.Close()
+		c, _ := newAPIClient(Config{APIKey: "KEY", URL: server.URL, MaxRetries: 1})
+		delays := recordSleeps(c)
+
+		_, err := c.GetMovieDetails(ctx, 603)
+		if !errors.Is(err, ErrUnavailable) {
+			t.Errorf("erreur = %v, want ErrUnavailable", err)
+		}
+		// La clé d'API ne doit pas fuiter dans le message d'erreur
+		if err != nil && strings.Contains(err.Error(), "KEY") {
+			t.Errorf("clé d'API dans l'erreur: %v", err)
+		}
+		if len(*delays) != 1 {
+			t.Errorf("attentes = %v, want une nouvelle tentative", *delays)
+		}
+	})
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"30", 30 * time.Second},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0},
+		{"bientôt", 0},
+	}
+	for _, tt := range tests {
+		resp := &http.Response{Header: http.Header{"Retry-After": {tt.value}}}
+		if got := retryAfter(resp, now); got != tt.want {
+			t.Errorf("retryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRateLimiterShared(t *testing.T) {
+	server, requests := newFlakyAPI(t, 0, 0, nil)
+	c, _ := newAPIClient(Config{APIKey: "KEY", URL: server.URL, RateLimit: 20})
+
+	// 20 requêtes/s avec une rafale de 20: 30 requêtes concurrentes prennent au moins ~0,5s
+	start := time.Now()
+	done := make(chan error)
+	for i := 0; i < 30; i++ {
+		go func() {
+			_, err := c.GetMovieDetails(context.Background(), 603)
+			done <- err
+		}()
+	}
+	for i := 0; i < 30; i++ {
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("30 requêtes en %v, le limiteur n'est pas partagé", elapsed)
+	}
+	if requests.Load() != 30 {
+		t.Errorf("requêtes = %d, want 30", requests.Load())
+	}
+}
@@ -0,0 +1,103 @@
+package tmdb
+
+import "fmt"
+
+// Show représente une série avec ses métadonnées TMDB
+type Show struct {
+	ID              int          `json:"id"`
+	Name            string       `json:"name"`
+	OriginalName    string       `json:"original_name"`
+	Overview        string       `json:"overview"`
+	FirstAirDate    string       `json:"first_air_date"`
+	PosterPath      string       `json:"poster_path"`
+	BackdropPath    string       `json:"backdrop_path"`
+	VoteAverage     float64      `json:"vote_average"`
+	VoteCount       int          `json:"vote_count"`
+	EpisodeRuntime  int          `json:"episode_runtime"` // durée typique d'un épisode, en minutes
+	NumberOfSeasons int          `json:"number_of_seasons"`
+	Tagline         string       `json:"tagline"`
+	IMDbID          string       `json:"imdb_id"`
+	Genres          []string     `json:"genres"`
+	Networks        []string     `json:"networks"`
+	Creators        []string     `json:"creators"`
+	Cast            []CastMember `json:"cast"`
+
+	Translations      []Translation      `json:"translations"`
+	AlternativeTitles []AlternativeTitle `json:"alternative_titles"`
+}
+
+// Season représente une saison d'une série et ses épisodes
+type Season struct {
+	ShowID       int       `json:"show_id"`
+	SeasonNumber int       `json:"season_number"`
+	Name         string    `json:"name"`
+	Overview     string    `json:"overview"`
+	AirDate      string    `json:"air_date"`
+	PosterPath   string    `json:"poster_path"`
+	Episodes     []Episode `json:"episodes"`
+}
+
+// Episode représente un épisode d'une saison
+type Episode struct {
+	SeasonNumber  int     `json:"season_number"`
+	EpisodeNumber int     `json:"episode_number"`
+	Name          string  `json:"name"`
+	Overview      string  `json:"overview"`
+	AirDate       string  `json:"air_date"`
+	Runtime       int     `json:"runtime"`
+	VoteAverage   float64 `json:"vote_average"`
+	StillPath     string  `json:"still_path"`
+}
+
+// Year retourne l'année de première diffusion de la série
+func (s *Show) Year() string {
+	return yearOf(s.FirstAirDate)
+}
+
+// PosterURL retourne l'URL complète du poster
+func (s *Show) PosterURL(size string) string {
+	if s.PosterPath == "" {
+		return ""
+	}
+	if size == "" {
+		size = "w500"
+	}
+	return imageURL(size, s.PosterPath)
+}
+
+// IMDbURL retourne l'URL IMDb de la série
+func (s *Show) IMDbURL() string {
+	if s.IMDbID == "" {
+		return ""
+	}
+	return imdbURL(s.IMDbID)
+}
+
+// TMDbURL retourne l'URL TMDB de la série
+func (s *Show) TMDbURL() string {
+	return fmt.Sprintf("https://www.themoviedb.org/tv/%d", s.ID)
+}
+
+// Episode retourne l'épisode de numéro number, nil s'il n'existe pas dans la saison
+func (s *Season) Episode(number int) *Episode {
+	for i := range s.Episodes {
+		if s.Episodes[i].EpisodeNumber == number {
+			return &s.Episodes[i]
+		}
+	}
+	return nil
+}
+
+// Code retourne le code de l'épisode au format SxxEyy
+func (e *Episode) Code() string {
+	return EpisodeCode(e.SeasonNumber, e.EpisodeNumber)
+}
+
+// EpisodeCode formate un numéro de saison et d'épisodes: S01E02, S01E02E03, ou S01 sans épisode
+func EpisodeCode(season int, episodes ...int) string {
+	code := fmt.Sprintf("S%02d", season)
+	for _, e := range episodes {
+		code += fmt.Sprintf("E%02d", e)
+	}
+	return code
+}
@@ -0,0 +1,56 @@
+package torrent
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ParseAnnounceTiers convertit une liste d'entrées en tiers d'annonce (BEP 12).
+// Chaque entrée correspond à un tier, les URLs d'un même tier étant séparées par des virgules.
+func ParseAnnounceTiers(entries []string) ([][]string, error) {
+	var tiers [][]string
+
+	for _, entry := range entries {
+		var tier []string
+		for _, raw := range strings.Split(entry, ",") {
+			raw = strings.TrimSpace(raw)
+			if raw == "" {
+				continue
+			}
+			if err := ValidateAnnounceURL(raw); err != nil {
+				return nil, err
+			}
+			tier = append(tier, raw)
+		}
+		if len(tier) > 0 {
+			tiers = append(tiers, tier)
+		}
+	}
+
+	return tiers, nil
+}
+
+// ValidateAnnounceURL vérifie qu'une URL d'annonce est utilisable par un client BitTorrent
+func ValidateAnnounceURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("URL d'annonce invalide %q: %w", raw, err)
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "udp", "ws", "wss":
+	default:
+		return fmt.Errorf("URL d'annonce invalide %q: schéma %q non supporté", raw, u.Scheme)
+	}
+
+	if u.Host == "" {
+		return fmt.Errorf("URL d'annonce invalide %q: hôte manquant", raw)
+	}
+
+	if strings.ToLower(u.Scheme) == "udp" && u.Port() == "" {
+		return fmt.Errorf("URL d'annonce invalide %q: port manquant", raw)
+	}
+
+	return nil
+}
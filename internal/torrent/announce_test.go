@@ -0,0 +1,103 @@
+package torrent
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseAnnounceTiers(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []string
+		want    [][]string
+		wantErr string
+	}{
+		{
+			name:    "Un tracker",
+			entries: []string{"https://tracker.example/announce"},
+			want:    [][]string{{"https://tracker.example/announce"}},
+		},
+		{
+			name:    "Plusieurs tiers",
+			entries: []string{"https://a.example/announce", "udp://b.example:6969/announce"},
+			want:    [][]string{{"https://a.example/announce"}, {"udp://b.example:6969/announce"}},
+		},
+		{
+			name:    "Plusieurs URLs dans un tier",
+			entries: []string{" https://a.example/announce , wss://b.example/announce "},
+			want:    [][]string{{"https://a.example/announce", "wss://b.example/announce"}},
+		},
+		{
+			name:    "Tiers et URLs vides ignorés",
+			entries: []string{"", " , ", "https://a.example/announce,,", ","},
+			want:    [][]string{{"https://a.example/announce"}},
+		},
+		{
+			name:    "Aucune entrée",
+			entries: nil,
+			want:    nil,
+		},
+		{
+			name:    "Schéma invalide",
+			entries: []string{"https://a.example/announce", "ftp://b.example/announce"},
+			wantErr: "schéma",
+		},
+		{
+			name:    "URL invalide dans un tier",
+			entries: []string{"https://a.example/announce,udp://b.example/announce"},
+			wantErr: "port manquant",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAnnounceTiers(tt.entries)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("erreur = %v, attendu %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tiers = %q, attendu %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAnnounceURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr string
+	}{
+		{"http://tracker.example/announce", ""},
+		{"https://tracker.example/announce?passkey=abc", ""},
+		{"HTTPS://tracker.example/announce", ""},
+		{"udp://tracker.example:6969/announce", ""},
+		{"ws://tracker.example/announce", ""},
+		{"wss://tracker.example/announce", ""},
+		{"udp://tracker.example/announce", "port manquant"},
+		{"ftp://tracker.example/announce", "schéma"},
+		{"magnet:?xt=urn:btih:abc", "schéma"},
+		{"tracker.example/announce", "schéma"},
+		{"https:///announce", "hôte manquant"},
+		{"http://tracker.example:port/announce", "invalide"},
+	}
+
+	for _, tt := range tests {
+		err := ValidateAnnounceURL(tt.url)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("ValidateAnnounceURL(%q) = %v", tt.url, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("ValidateAnnounceURL(%q) = %v, attendu %q", tt.url, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,355 @@
+package torrent
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cacheExt est l'extension des fichiers du cache de pièces
+const cacheExt = ".pieces"
+
+// PieceCache est un cache disque des hashes SHA-1 de pièces, pour ne pas rehacher les
+// données inchangées. Chaque pièce est identifiée par les fichiers qui la composent
+// (chemin, taille, date de modification, offset) et la taille de pièce; les entrées sont
+// regroupées dans un fichier par fichier source.
+type PieceCache struct {
+	dir     string
+	maxSize int64
+
+	mu      sync.Mutex
+	buckets map[string]*cacheBucket
+}
+
+// cacheHeader identifie le fichier source d'un groupe d'entrées du cache
+type cacheHeader struct {
+	Path        string
+	Size        int64
+	ModTime     int64
+	PieceLength int64
+}
+
+// cacheBucket regroupe les pièces commençant dans un même fichier source
+type cacheBucket struct {
+	header cacheHeader
+	pieces map[[sha256.Size]byte][sha1.Size]byte
+	dirty  bool
+}
+
+// PruneStats résume le nettoyage du cache
+type PruneStats struct {
+	Removed   int   // fichiers supprimés
+	Stale     int   // dont fichiers dont la source a changé ou disparu
+	Freed     int64 // octets libérés
+	Remaining int64 // taille restante du cache
+}
+
+// OpenPieceCache ouvre (et crée si besoin) un cache de pièces dans dir.
+// maxSize limite la taille totale du cache sur disque (0 = pas de limite).
+func OpenPieceCache(dir string, maxSize int64) (*PieceCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("erreur création dossier cache: %w", err)
+	}
+	return &PieceCache{dir: dir, maxSize: maxSize, buckets: make(map[string]*cacheBucket)}, nil
+}
+
+// cacheLookup associe chaque pièce d'un flux de fichiers à sa clé de cache
+type cacheLookup struct {
+	cache   *PieceCache
+	keys    [][sha256.Size]byte
+	buckets []*cacheBucket // nil si la pièce ne peut pas être mise en cache
+}
+
+// lookup calcule les clés de cache des pièces d'une liste de fichiers et charge les groupes concernés
+func (c *PieceCache) lookup(files []sourceFile, pieceLength int64) *cacheLookup {
+	type fileStat struct {
+		path     string
+		size     int64
+		modTime  int64
+		bucketID string
+	}
+
+	// Un fichier absent ou de taille inattendue n'est jamais mis en cache
+	stats := make([]*fileStat, len(files))
+	offsets := make([]int64, len(files)+1)
+	for i, f := range files {
+		offsets[i+1] = offsets[i] + f.Length
+		if f.Padding {
+			continue
+		}
+		path, err := filepath.Abs(f.Path)
+		if err != nil {
+			continue
+		}
+		fi, err := os.Stat(path)
+		if err != nil || fi.Size() != f.Length {
+			continue
+		}
+		header := cacheHeader{Path: path, Size: fi.Size(), ModTime: fi.ModTime().UnixNano(), PieceLength: pieceLength}
+		stats[i] = &fileStat{path: path, size: header.Size, modTime: header.ModTime, bucketID: header.id()}
+	}
+
+	total := offsets[len(files)]
+	numPieces := int((total + pieceLength - 1) / pieceLength)
+	l := &cacheLookup{
+		cache:   c,
+		keys:    make([][sha256.Size]byte, numPieces),
+		buckets: make([]*cacheBucket, numPieces),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	first := 0
+	for index := 0; index < numPieces; index++ {
+		start := int64(index) * pieceLength
+		end := min(start+pieceLength, total)
+		for first < len(files) && offsets[first+1] <= start {
+			first++
+		}
+
+		h := sha256.New()
+		fmt.Fprintf(h, "%d\n", pieceLength)
+		var bucket *cacheBucket
+		usable := true
+		for j := first; j < len(files) && offsets[j] < end; j++ {
+			segStart := max(start, offsets[j]) - offsets[j]
+			segEnd := min(end, offsets[j+1]) - offsets[j]
+			if segEnd <= segStart {
+				continue
+			}
+			if files[j].Padding {
+				fmt.Fprintf(h, "pad %d\n", segEnd-segStart)
+				continue
+			}
+			st := stats[j]
+			if st == nil {
+				usable = false
+				break
+			}
+			fmt.Fprintf(h, "%s\x00%d\x00%d\x00%d\x00%d\n", st.path, st.size, st.modTime, segStart, segEnd-segStart)
+			if bucket == nil {
+				bucket = c.bucket(st.bucketID, cacheHeader{Path: st.path, Size: st.size, ModTime: st.modTime, PieceLength: pieceLength})
+			}
+		}
+		if !usable || bucket == nil {
+			continue
+		}
+
+		copy(l.keys[index][:], h.Sum(nil))
+		l.buckets[index] = bucket
+	}
+
+	return l
+}
+
+// get copie le hash en cache de la pièce index dans dst, s'il existe
+func (l *cacheLookup) get(index int, dst []byte) bool {
+	bucket := l.buckets[index]
+	if bucket == nil {
+		return false
+	}
+
+	l.cache.mu.Lock()
+	defer l.cache.mu.Unlock()
+	sum, ok := bucket.pieces[l.keys[index]]
+	if ok {
+		copy(dst, sum[:])
+	}
+	return ok
+}
+
+// put enregistre le hash calculé de la pièce index
+func (l *cacheLookup) put(index int, sum [sha1.Size]byte) {
+	bucket := l.buckets[index]
+	if bucket == nil {
+		return
+	}
+
+	l.cache.mu.Lock()
+	defer l.cache.mu.Unlock()
+	bucket.pieces[l.keys[index]] = sum
+	bucket.dirty = true
+}
+
+// id retourne l'identifiant du groupe d'entrées d'un fichier source
+func (h cacheHeader) id() string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d\x00%d\x00%d", h.Path, h.Size, h.ModTime, h.PieceLength)))
+	return hex.EncodeToString(sum[:16])
+}
+
+// bucket retourne le groupe d'entrées id, chargé depuis le disque si besoin (c.mu verrouillé)
+func (c *PieceCache) bucket(id string, header cacheHeader) *cacheBucket {
+	if b, ok := c.buckets[id]; ok {
+		return b
+	}
+
+	b := &cacheBucket{header: header, pieces: make(map[[sha256.Size]byte][sha1.Size]byte)}
+	path := filepath.Join(c.dir, id+cacheExt)
+	if stored, pieces, err := readCacheFile(path); err == nil && stored == header {
+		b.pieces = pieces
+		// La date de modification sert d'horodatage d'utilisation pour l'éviction
+		now := time.Now()
+		os.Chtimes(path, now, now)
+	}
+
+	c.buckets[id] = b
+	return b
+}
+
+// Save écrit sur disque les entrées ajoutées puis applique la limite de taille du cache
+func (c *PieceCache) Save() error {
+	c.mu.Lock()
+	for id, b := range c.buckets {
+		if !b.dirty {
+			continue
+		}
+		if err := writeCacheFile(filepath.Join(c.dir, id+cacheExt), b); err != nil {
+			c.mu.Unlock()
+			return err
+		}
+		b.dirty = false
+	}
+	c.mu.Unlock()
+
+	_, err := c.Prune(c.maxSize)
+	return err
+}
+
+// Prune supprime les entrées dont le fichier source a changé ou disparu, puis les moins
+// récemment utilisées jusqu'à ce que le cache tienne dans maxSize octets (0 = pas de limite)
+func (c *PieceCache) Prune(maxSize int64) (PruneStats, error) {
+	var stats PruneStats
+
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return stats, fmt.Errorf("erreur lecture cache: %w", err)
+	}
+
+	type cacheFile struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var kept []cacheFile
+
+	remove := func(path string, size int64) error {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("erreur suppression cache: %w", err)
+		}
+		stats.Removed++
+		stats.Freed += size
+		return nil
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), cacheExt) {
+			continue
+		}
+		path := filepath.Join(c.dir, entry.Name())
+		fi, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		header, err := readCacheHeader(path)
+		if err != nil || !header.current() {
+			stats.Stale++
+			if err := remove(path, fi.Size()); err != nil {
+				return stats, err
+			}
+			continue
+		}
+		kept = append(kept, cacheFile{path: path, size: fi.Size(), modTime: fi.ModTime()})
+		stats.Remaining += fi.Size()
+	}
+
+	if maxSize > 0 && stats.Remaining > maxSize {
+		sort.Slice(kept, func(i, j int) bool { return kept[i].modTime.Before(kept[j].modTime) })
+		for _, f := range kept {
+			if stats.Remaining <= maxSize {
+				break
+			}
+			if err := remove(f.path, f.size); err != nil {
+				return stats, err
+			}
+			stats.Remaining -= f.size
+		}
+	}
+
+	return stats, nil
+}
+
+// current indique si le fichier source correspond toujours à l'entrée du cache
+func (h cacheHeader) current() bool {
+	fi, err := os.Stat(h.Path)
+	return err == nil && fi.Size() == h.Size && fi.ModTime().UnixNano() == h.ModTime
+}
+
+// writeCacheFile écrit un groupe d'entrées (en-tête puis hashes) via un fichier temporaire
+func writeCacheFile(path string, b *cacheBucket) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("erreur écriture cache: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	enc := gob.NewEncoder(tmp)
+	if err := enc.Encode(b.header); err != nil {
+		tmp.Close()
+		return fmt.Errorf("erreur écriture cache: %w", err)
+	}
+	if err := enc.Encode(b.pieces); err != nil {
+		tmp.Close()
+		return fmt.Errorf("erreur écriture cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("erreur écriture cache: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("erreur écriture cache: %w", err)
+	}
+	return nil
+}
+
+// readCacheFile lit un groupe d'entrées complet
+func readCacheFile(path string) (cacheHeader, map[[sha256.Size]byte][sha1.Size]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return cacheHeader{}, nil, err
+	}
+	defer f.Close()
+
+	var header cacheHeader
+	var pieces map[[sha256.Size]byte][sha1.Size]byte
+	dec := gob.NewDecoder(f)
+	if err := dec.Decode(&header); err != nil {
+		return cacheHeader{}, nil, err
+	}
+	if err := dec.Decode(&pieces); err != nil {
+		return cacheHeader{}, nil, err
+	}
+	return header, pieces, nil
+}
+
+// readCacheHeader lit seulement l'en-tête d'un groupe d'entrées
+func readCacheHeader(path string) (cacheHeader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return cacheHeader{}, err
+	}
+	defer f.Close()
+
+	var header cacheHeader
+	err = gob.NewDecoder(f).Decode(&header)
+	return header, err
+}
@@ -0,0 +1,124 @@
+package torrent
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReadPiecesCache(t *testing.T) {
+	dir := t.TempDir()
+	const pieceLength = 16 * 1024
+
+	var files []sourceFile
+	for i, size := range []int{5*pieceLength + 100, 2*pieceLength - 7, 3000} {
+		path := filepath.Join(dir, "data", string(rune('a'+i)))
+		writeRandomFile(t, path, size, int64(i))
+		files = append(files, sourceFile{Path: path, Length: int64(size)})
+	}
+
+	cache, err := OpenPieceCache(filepath.Join(dir, "cache"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := readV1Pieces(context.Background(), files, pieceLength, 2, nil, cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := readV1Pieces(context.Background(), files, pieceLength, 2, nil, nil)
+	if !bytes.Equal(first, want) {
+		t.Fatal("le premier passage avec cache diffère du hachage sans cache")
+	}
+
+	// Contenu modifié sans changer taille ni date: le cache (rouvert) fait foi et rien n'est relu
+	stat, _ := os.Stat(files[0].Path)
+	writeRandomFile(t, files[0].Path, int(files[0].Length), 42)
+	os.Chtimes(files[0].Path, stat.ModTime(), stat.ModTime())
+
+	cache, _ = OpenPieceCache(filepath.Join(dir, "cache"), 0)
+	var hashed int64
+	second, err := readV1Pieces(context.Background(), files, pieceLength, 2, func(h, total int64) { hashed = h }, cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(second, first) {
+		t.Error("les hashes en cache n'ont pas été réutilisés")
+	}
+	if hashed != streamLength(files) {
+		t.Errorf("progression = %d, want %d", hashed, streamLength(files))
+	}
+
+	// Un fichier modifié (date changée) est rehaché, ainsi que les pièces qu'il partage
+	writeRandomFile(t, files[1].Path, int(files[1].Length), 43)
+	os.Chtimes(files[1].Path, time.Now().Add(time.Hour), time.Now().Add(time.Hour))
+
+	cache, _ = OpenPieceCache(filepath.Join(dir, "cache"), 0)
+	third, err := readV1Pieces(context.Background(), files, pieceLength, 2, nil, cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fresh, _ := readV1Pieces(context.Background(), files, pieceLength, 2, nil, nil)
+	// Les 5 premières pièces n'appartiennent qu'au fichier a (toujours servi par le cache)
+	if !bytes.Equal(third[:5*20], first[:5*20]) {
+		t.Error("les pièces du fichier inchangé devraient venir du cache")
+	}
+	if !bytes.Equal(third[5*20:], fresh[5*20:]) {
+		t.Error("les pièces du fichier modifié devraient être rehachées")
+	}
+}
+
+func TestPieceCachePrune(t *testing.T) {
+	dir := t.TempDir()
+	cacheDir := filepath.Join(dir, "cache")
+	const pieceLength = 16 * 1024
+
+	cache, err := OpenPieceCache(cacheDir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for i := 0; i < 3; i++ {
+		path := filepath.Join(dir, string(rune('a'+i)))
+		writeRandomFile(t, path, 20*pieceLength, int64(i))
+		if _, err := readV1Pieces(context.Background(), []sourceFile{{Path: path, Length: 20 * pieceLength}}, pieceLength, 2, nil, cache); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+
+	cacheFiles := func() int {
+		entries, _ := os.ReadDir(cacheDir)
+		return len(entries)
+	}
+	if n := cacheFiles(); n != 3 {
+		t.Fatalf("%d fichiers de cache, want 3", n)
+	}
+
+	// Source supprimée: entrée obsolète
+	os.Remove(paths[0])
+	stats, err := cache.Prune(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Removed != 1 || stats.Stale != 1 || cacheFiles() != 2 {
+		t.Errorf("stats = %+v, %d fichiers restants", stats, cacheFiles())
+	}
+
+	// Limite de taille: l'entrée la moins récemment utilisée part en premier
+	entries, _ := os.ReadDir(cacheDir)
+	old := time.Now().Add(-time.Hour)
+	os.Chtimes(filepath.Join(cacheDir, entries[0].Name()), old, old)
+	stats, err = cache.Prune(stats.Remaining - 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Removed != 1 || stats.Stale != 0 || cacheFiles() != 1 {
+		t.Errorf("stats = %+v, %d fichiers restants", stats, cacheFiles())
+	}
+	if _, err := os.Stat(filepath.Join(cacheDir, entries[0].Name())); !os.IsNotExist(err) {
+		t.Error("l'entrée la plus ancienne aurait dû être supprimée")
+	}
+}
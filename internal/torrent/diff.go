@@ -0,0 +1,417 @@
+package torrent
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Statuts d'un fichier dans la comparaison de deux torrents
+const (
+	FileAdded    = "added"    // présent seulement dans le second torrent
+	FileRemoved  = "removed"  // présent seulement dans le premier torrent
+	FileResized  = "resized"  // même chemin, taille différente
+	FileModified = "modified" // même chemin et même taille, contenu différent (v2)
+	FileRenamed  = "renamed"  // même contenu (v2) sous un autre chemin
+)
+
+// DiffSide résume un des deux torrents comparés
+type DiffSide struct {
+	Torrent     string     `json:"torrent"`
+	Name        string     `json:"name"`
+	Version     Version    `json:"version"`
+	InfoHash    InfoHashes `json:"info_hash"`
+	TotalSize   int64      `json:"total_size"`
+	PieceLength int64      `json:"piece_length"`
+	Pieces      int        `json:"pieces"`
+	Files       int        `json:"files"`
+}
+
+// FieldDiff décrit un champ dont la valeur diffère entre les deux torrents
+type FieldDiff struct {
+	Field string `json:"field"`
+	A     string `json:"a"`
+	B     string `json:"b"`
+}
+
+// FileDiff décrit un fichier qui diffère entre les deux torrents
+type FileDiff struct {
+	Path    string `json:"path"`
+	Status  string `json:"status"`
+	LengthA int64  `json:"length_a,omitempty"`
+	LengthB int64  `json:"length_b,omitempty"`
+	NewPath string `json:"new_path,omitempty"` // nouveau chemin d'un fichier renommé
+}
+
+// PieceRange est une suite de pièces identiques dans les deux torrents
+type PieceRange struct {
+	StartA int      `json:"start_a"`
+	StartB int      `json:"start_b"`
+	Count  int      `json:"count"`
+	Files  []string `json:"files,omitempty"` // fichiers du premier torrent entièrement compris dans la plage
+}
+
+// TorrentDiff est le résultat de la comparaison de deux torrents
+type TorrentDiff struct {
+	A               DiffSide     `json:"a"`
+	B               DiffSide     `json:"b"`
+	Identical       bool         `json:"identical"` // même dictionnaire info (même infohash)
+	SizeDelta       int64        `json:"size_delta"`
+	Fields          []FieldDiff  `json:"fields"`
+	Files           []FileDiff   `json:"files"`
+	IdenticalFiles  int          `json:"identical_files"`
+	PieceHash       string       `json:"piece_hash,omitempty"` // sha1 (v1) ou sha256 (v2), vide si non comparable
+	IdenticalPieces int          `json:"identical_pieces"`
+	PieceRanges     []PieceRange `json:"piece_ranges"`
+	Notes           []string     `json:"notes"`
+}
+
+// diffTorrent regroupe ce qui est nécessaire à la comparaison d'un torrent
+type diffTorrent struct {
+	inspection *Inspection
+	info       infoDict
+	tf         *torrentFile
+}
+
+// Diff compare deux fichiers torrent: champs (private, source, trackers...), liste des fichiers,
+// tailles et plages de pièces identiques (même contenu haché avec la même taille de pièce)
+func Diff(pathA, pathB string) (*TorrentDiff, error) {
+	a, err := loadDiffTorrent(pathA)
+	if err != nil {
+		return nil, err
+	}
+	b, err := loadDiffTorrent(pathB)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &TorrentDiff{
+		A:           a.side(),
+		B:           b.side(),
+		SizeDelta:   b.inspection.TotalSize - a.inspection.TotalSize,
+		Fields:      []FieldDiff{},
+		Files:       []FileDiff{},
+		PieceRanges: []PieceRange{},
+		Notes:       []string{},
+	}
+	d.Identical = (a.inspection.InfoHash.V1 != "" && a.inspection.InfoHash.V1 == b.inspection.InfoHash.V1) ||
+		(a.inspection.InfoHash.V2 != "" && a.inspection.InfoHash.V2 == b.inspection.InfoHash.V2)
+
+	d.Fields = diffFields(a.inspection, b.inspection)
+	d.Files, d.IdenticalFiles = diffFiles(a, b)
+	d.diffPieces(a, b)
+
+	return d, nil
+}
+
+// loadDiffTorrent charge un torrent à comparer; le fichier n'est lu et décodé qu'une fois
+func loadDiffTorrent(torrentPath string) (*diffTorrent, error) {
+	tf, err := loadTorrentFile(torrentPath)
+	if err != nil {
+		return nil, err
+	}
+	info, err := tf.unmarshalInfo()
+	if err != nil {
+		return nil, err
+	}
+	inspection, err := inspectInfo(torrentPath, tf, &info)
+	if err != nil {
+		return nil, err
+	}
+	return &diffTorrent{inspection: inspection, info: info, tf: tf}, nil
+}
+
+// side résume le torrent pour le rapport
+func (t *diffTorrent) side() DiffSide {
+	files := 0
+	for _, f := range t.inspection.Files {
+		if !f.Padding {
+			files++
+		}
+	}
+	return DiffSide{
+		Torrent:     t.inspection.Torrent,
+		Name:        t.inspection.Name,
+		Version:     t.inspection.Version,
+		InfoHash:    t.inspection.InfoHash,
+		TotalSize:   t.inspection.TotalSize,
+		PieceLength: t.inspection.PieceLength,
+		Pieces:      t.inspection.Pieces,
+		Files:       files,
+	}
+}
+
+// diffFields compare les champs qui font qu'un tracker distingue deux torrents
+func diffFields(a, b *Inspection) []FieldDiff {
+	fields := []FieldDiff{
+		{Field: "name", A: a.Name, B: b.Name},
+		{Field: "version", A: string(a.Version), B: string(b.Version)},
+		{Field: "piece_length", A: strconv.FormatInt(a.PieceLength, 10), B: strconv.FormatInt(b.PieceLength, 10)},
+		{Field: "private", A: strconv.FormatBool(a.Private), B: strconv.FormatBool(b.Private)},
+		{Field: "source", A: a.Source, B: b.Source},
+		{Field: "announce", A: formatTiers(a.Trackers), B: formatTiers(b.Trackers)},
+		{Field: "web_seeds", A: strings.Join(a.WebSeeds, ", "), B: strings.Join(b.WebSeeds, ", ")},
+	}
+
+	diffs := []FieldDiff{}
+	for _, f := range fields {
+		if f.A != f.B {
+			diffs = append(diffs, f)
+		}
+	}
+	return diffs
+}
+
+// formatTiers formate les trackers: URLs d'un tier séparées par des virgules, tiers par " | "
+func formatTiers(tiers [][]string) string {
+	parts := make([]string, len(tiers))
+	for i, tier := range tiers {
+		parts[i] = strings.Join(tier, ", ")
+	}
+	return strings.Join(parts, " | ")
+}
+
+// diffFile est un fichier (hors bourrage) indexé par chemin pour la comparaison
+type diffFile struct {
+	length int64
+	root   string // pieces root v2, vide si inconnu
+}
+
+// contentFiles retourne les fichiers du torrent par chemin, avec leur pieces root v2 si disponible.
+// Un torrent mono-fichier est indexé sous le chemin vide: deux releases du même fichier sous
+// des noms différents sont comparées fichier à fichier (la différence de nom est un champ).
+func (t *diffTorrent) contentFiles() map[string]diffFile {
+	files := make(map[string]diffFile)
+	single := t.info.isSingleFile()
+
+	if t.info.FileTree != nil {
+		t.info.FileTree.walk(nil, func(parts []string, f fileTreeFile) {
+			key := path.Join(parts...)
+			if single {
+				key = ""
+			}
+			files[key] = diffFile{length: f.Length, root: string(f.PiecesRoot)}
+		})
+		return files
+	}
+
+	for _, f := range t.inspection.Files {
+		if f.Padding {
+			continue
+		}
+		key := f.Path
+		if single {
+			key = ""
+		}
+		files[key] = diffFile{length: f.Length}
+	}
+	return files
+}
+
+// diffFiles compare les listes de fichiers et retourne les différences et le nombre de fichiers identiques
+func diffFiles(a, b *diffTorrent) ([]FileDiff, int) {
+	filesA, filesB := a.contentFiles(), b.contentFiles()
+	single := a.info.isSingleFile() && b.info.isSingleFile()
+	display := func(key string, t *diffTorrent) string {
+		if key == "" {
+			return t.inspection.Name
+		}
+		return key
+	}
+
+	diffs := []FileDiff{}
+	identical := 0
+	var removed, added []string
+	for key, fa := range filesA {
+		fb, ok := filesB[key]
+		switch {
+		case !ok:
+			removed = append(removed, key)
+		case fa.length != fb.length:
+			diffs = append(diffs, FileDiff{Path: display(key, a), Status: FileResized, LengthA: fa.length, LengthB: fb.length})
+		case fa.root != "" && fb.root != "" && fa.root != fb.root:
+			diffs = append(diffs, FileDiff{Path: display(key, a), Status: FileModified, LengthA: fa.length, LengthB: fb.length})
+		default:
+			identical++
+		}
+	}
+	for key := range filesB {
+		if _, ok := filesA[key]; !ok {
+			added = append(added, key)
+		}
+	}
+	if single {
+		// Les deux fichiers uniques ont toujours été comparés entre eux
+		removed, added = nil, nil
+	}
+
+	// Un fichier supprimé et un fichier ajouté de même pieces root sont un renommage
+	addedByRoot := make(map[string]string)
+	for _, key := range added {
+		if root := filesB[key].root; root != "" && filesB[key].length > 0 {
+			addedByRoot[root] = key
+		}
+	}
+	renamed := make(map[string]bool)
+	for _, key := range removed {
+		fa := filesA[key]
+		if newKey, ok := addedByRoot[fa.root]; ok && fa.root != "" && !renamed[newKey] {
+			renamed[newKey] = true
+			diffs = append(diffs, FileDiff{Path: key, Status: FileRenamed, LengthA: fa.length, LengthB: fa.length, NewPath: newKey})
+			continue
+		}
+		diffs = append(diffs, FileDiff{Path: key, Status: FileRemoved, LengthA: fa.length})
+	}
+	for _, key := range added {
+		if !renamed[key] {
+			diffs = append(diffs, FileDiff{Path: key, Status: FileAdded, LengthB: filesB[key].length})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Path < diffs[j].Path })
+	return diffs, identical
+}
+
+// pieceList contient les hashes de pièces d'un torrent dans l'ordre du flux et, pour chaque fichier,
+// la plage de pièces qu'il occupe
+type pieceList struct {
+	hashes  []string
+	files   []filePieces
+	padding []bool // pièces sans données de fichier (bourrage BEP 47 seul), nil en v2
+}
+
+// comparable indique si la pièce index contient des données: une pièce de bourrage seul
+// (que des zéros) est identique d'un torrent à l'autre sans rien dire du contenu
+func (l *pieceList) comparable(index int) bool {
+	return l.padding == nil || !l.padding[index]
+}
+
+// filePieces est la plage de pièces [first, last] occupée par un fichier
+type filePieces struct {
+	path        string
+	first, last int
+}
+
+// diffPieces cherche les plages de pièces identiques, si les deux torrents sont comparables
+func (d *TorrentDiff) diffPieces(a, b *diffTorrent) {
+	if a.info.PieceLength != b.info.PieceLength {
+		d.Notes = append(d.Notes, fmt.Sprintf("tailles de pièce différentes (%s / %s): pièces non comparables",
+			formatSize(a.info.PieceLength), formatSize(b.info.PieceLength)))
+		return
+	}
+
+	var listA, listB *pieceList
+	switch {
+	case len(a.info.Pieces) > 0 && len(b.info.Pieces) > 0:
+		d.PieceHash = "sha1"
+		listA, listB = a.v1Pieces(), b.v1Pieces()
+	case a.info.FileTree != nil && b.info.FileTree != nil:
+		d.PieceHash = "sha256"
+		listA, listB = a.v2Pieces(), b.v2Pieces()
+	default:
+		d.Notes = append(d.Notes, "torrent v1 et torrent v2 uniquement: pièces non comparables")
+		return
+	}
+
+	// Première occurrence de chaque hash dans le second torrent
+	indexB := make(map[string]int, len(listB.hashes))
+	for j := len(listB.hashes) - 1; j >= 0; j-- {
+		if listB.comparable(j) {
+			indexB[listB.hashes[j]] = j
+		}
+	}
+
+	for i := 0; i < len(listA.hashes); {
+		j, ok := indexB[listA.hashes[i]]
+		if !ok || !listA.comparable(i) {
+			i++
+			continue
+		}
+		n := 1
+		for i+n < len(listA.hashes) && j+n < len(listB.hashes) && listA.hashes[i+n] == listB.hashes[j+n] &&
+			listA.comparable(i+n) && listB.comparable(j+n) {
+			n++
+		}
+
+		r := PieceRange{StartA: i, StartB: j, Count: n}
+		for _, f := range listA.files {
+			if f.first >= i && f.last < i+n {
+				r.Files = append(r.Files, f.path)
+			}
+		}
+		d.PieceRanges = append(d.PieceRanges, r)
+		d.IdenticalPieces += n
+		i += n
+	}
+}
+
+// v1Pieces découpe le champ pieces et situe chaque fichier dans le flux v1
+func (t *diffTorrent) v1Pieces() *pieceList {
+	list := &pieceList{}
+	for i := 0; i+sha1.Size <= len(t.info.Pieces); i += sha1.Size {
+		list.hashes = append(list.hashes, string(t.info.Pieces[i:i+sha1.Size]))
+	}
+
+	list.padding = make([]bool, len(list.hashes))
+	for i := range list.padding {
+		list.padding[i] = true
+	}
+
+	files, _ := t.info.torrentFiles("")
+	pl := t.info.PieceLength
+	var offset int64
+	for _, f := range files {
+		if !f.Padding && f.Length > 0 {
+			fp := filePieces{
+				path:  t.filePath(f),
+				first: int(offset / pl),
+				last:  int((offset + f.Length - 1) / pl),
+			}
+			list.files = append(list.files, fp)
+			for i := fp.first; i <= fp.last && i < len(list.padding); i++ {
+				list.padding[i] = false
+			}
+		}
+		offset += f.Length
+	}
+	return list
+}
+
+// v2Pieces reconstitue la liste des pièces v2 à partir des piece layers: chaque fichier commence
+// sur une nouvelle pièce; un fichier d'une seule pièce est représenté par son pieces root
+func (t *diffTorrent) v2Pieces() *pieceList {
+	list := &pieceList{}
+	single := t.info.isSingleFile()
+	t.info.FileTree.walk(nil, func(parts []string, f fileTreeFile) {
+		if f.Length == 0 {
+			return
+		}
+		first := len(list.hashes)
+		if layer, ok := t.tf.PieceLayers[string(f.PiecesRoot)]; ok && f.Length > t.info.PieceLength {
+			for i := 0; i+sha256.Size <= len(layer); i += sha256.Size {
+				list.hashes = append(list.hashes, layer[i:i+sha256.Size])
+			}
+		} else {
+			list.hashes = append(list.hashes, string(f.PiecesRoot))
+		}
+
+		p := path.Join(parts...)
+		if single {
+			p = t.inspection.Name
+		}
+		list.files = append(list.files, filePieces{path: p, first: first, last: len(list.hashes) - 1})
+	})
+	return list
+}
+
+// filePath retourne le chemin affiché d'un fichier du torrent
+func (t *diffTorrent) filePath(f sourceFile) string {
+	if f.Parts == nil {
+		return t.inspection.Name
+	}
+	return path.Join(f.Parts...)
+}
@@ -0,0 +1,248 @@
+package torrent
+
+import (
+	"context"
+	"crypto/sha1"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anacrolix/torrent/bencode"
+)
+
+// createDiffTorrent crée un torrent pour data avec la version, la taille de pièce et le profil donnés
+func createDiffTorrent(t *testing.T, data string, version Version, pieceSize int64, profile Profile) string {
+	t.Helper()
+
+	g := NewGenerator()
+	g.SetVersion(version)
+	g.SetPieceSize(pieceSize)
+	if err := g.ApplyProfile(profile); err != nil {
+		t.Fatal(err)
+	}
+	output := filepath.Join(t.TempDir(), profile.Name+".torrent")
+	if err := g.Create(context.Background(), data, output); err != nil {
+		t.Fatal(err)
+	}
+	return output
+}
+
+func TestDiffCrossSeed(t *testing.T) {
+	data := filepath.Join(t.TempDir(), "Release")
+	writeRandomFile(t, filepath.Join(data, "a.mkv"), 300000, 1)
+	writeRandomFile(t, filepath.Join(data, "b.nfo"), 2048, 2)
+
+	for _, version := range []Version{VersionV1, VersionV2, VersionHybrid} {
+		t.Run(string(version), func(t *testing.T) {
+			a := createDiffTorrent(t, data, version, 16<<10, Profile{
+				Name: "a", AnnounceList: [][]string{{"https://a.example/announce"}}, Source: "A", Private: true,
+			})
+			b := createDiffTorrent(t, data, version, 16<<10, Profile{
+				Name: "b", AnnounceList: [][]string{{"https://b.example/announce"}}, Source: "B", Private: true,
+			})
+
+			d, err := Diff(a, b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d.Identical {
+				t.Error("sources différentes: les infohash doivent différer")
+			}
+			fields := make(map[string]bool)
+			for _, f := range d.Fields {
+				fields[f.Field] = true
+			}
+			if len(fields) != 2 || !fields["source"] || !fields["announce"] {
+				t.Errorf("champs différents = %+v, attendu source et announce", d.Fields)
+			}
+			if len(d.Files) != 0 || d.IdenticalFiles != 2 {
+				t.Errorf("fichiers: %+v, %d identiques", d.Files, d.IdenticalFiles)
+			}
+			if len(d.PieceRanges) != 1 || len(d.PieceRanges[0].Files) != 2 {
+				t.Errorf("plages = %+v, attendu une seule plage couvrant les deux fichiers", d.PieceRanges)
+			}
+		})
+	}
+}
+
+func TestDiffFiles(t *testing.T) {
+	base := t.TempDir()
+	dataA := filepath.Join(base, "a", "Release")
+	dataB := filepath.Join(base, "b", "Release")
+	for _, data := range []string{dataA, dataB} {
+		writeRandomFile(t, filepath.Join(data, "film.mkv"), 200000, 1)
+	}
+	writeRandomFile(t, filepath.Join(dataA, "film.nfo"), 1000, 2)
+	writeRandomFile(t, filepath.Join(dataB, "film.nfo"), 1200, 2)
+	writeRandomFile(t, filepath.Join(dataA, "sample.mkv"), 50000, 3)
+	writeRandomFile(t, filepath.Join(dataB, "samples", "sample.mkv"), 50000, 3)
+	writeRandomFile(t, filepath.Join(dataB, "film.srt"), 5000, 4)
+	writeRandomFile(t, filepath.Join(dataA, "notes.txt"), 40000, 5)
+	writeRandomFile(t, filepath.Join(dataB, "notes.txt"), 40000, 6)
+
+	t.Run("v1", func(t *testing.T) {
+		a := createDiffTorrent(t, dataA, VersionV1, 16<<10, Profile{Name: "a"})
+		b := createDiffTorrent(t, dataB, VersionV1, 16<<10, Profile{Name: "b"})
+		d, err := Diff(a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		statuses := diffStatuses(d)
+		want := "film.nfo:resized film.srt:added sample.mkv:removed samples/sample.mkv:added"
+		if statuses != want {
+			t.Errorf("fichiers = %s, attendu %s", statuses, want)
+		}
+		// film.mkv est en tête du flux dans les deux torrents: ses pièces complètes sont identiques
+		if len(d.PieceRanges) == 0 || d.PieceRanges[0].StartA != 0 || d.PieceRanges[0].StartB != 0 ||
+			d.PieceRanges[0].Count != 200000/(16<<10) {
+			t.Errorf("plages = %+v", d.PieceRanges)
+		}
+		if d.SizeDelta != 200+5000 {
+			t.Errorf("écart de taille = %d", d.SizeDelta)
+		}
+	})
+
+	t.Run("v2", func(t *testing.T) {
+		a := createDiffTorrent(t, dataA, VersionV2, 16<<10, Profile{Name: "a"})
+		b := createDiffTorrent(t, dataB, VersionV2, 16<<10, Profile{Name: "b"})
+		d, err := Diff(a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Les pieces root v2 permettent de repérer renommages et modifications à taille égale
+		statuses := diffStatuses(d)
+		want := "film.nfo:resized film.srt:added notes.txt:modified sample.mkv:renamed"
+		if statuses != want {
+			t.Errorf("fichiers = %s, attendu %s", statuses, want)
+		}
+		covered := make(map[string]bool)
+		for _, r := range d.PieceRanges {
+			for _, f := range r.Files {
+				covered[f] = true
+			}
+		}
+		if !covered["film.mkv"] || !covered["sample.mkv"] || covered["notes.txt"] {
+			t.Errorf("fichiers couverts par des pièces identiques = %v", covered)
+		}
+	})
+}
+
+func TestDiffSingleFile(t *testing.T) {
+	dir := t.TempDir()
+	writeRandomFile(t, filepath.Join(dir, "Film.GroupeA.mkv"), 100000, 1)
+	data, err := os.ReadFile(filepath.Join(dir, "Film.GroupeA.mkv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "Film.GroupeB.mkv"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	a := createDiffTorrent(t, filepath.Join(dir, "Film.GroupeA.mkv"), VersionHybrid, 16<<10, Profile{Name: "a"})
+	b := createDiffTorrent(t, filepath.Join(dir, "Film.GroupeB.mkv"), VersionHybrid, 16<<10, Profile{Name: "b"})
+	d, err := Diff(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(d.Fields) != 1 || d.Fields[0].Field != "name" {
+		t.Errorf("champs différents = %+v, attendu name", d.Fields)
+	}
+	if len(d.Files) != 0 || d.IdenticalFiles != 1 {
+		t.Errorf("fichiers = %+v: le fichier unique doit être comparé malgré le renommage", d.Files)
+	}
+	if d.IdenticalPieces != d.A.Pieces {
+		t.Errorf("%d pièces identiques sur %d", d.IdenticalPieces, d.A.Pieces)
+	}
+}
+
+func TestDiffPieceLength(t *testing.T) {
+	data := filepath.Join(t.TempDir(), "film.mkv")
+	writeRandomFile(t, data, 100000, 1)
+
+	a := createDiffTorrent(t, data, VersionV1, 16<<10, Profile{Name: "a"})
+	b := createDiffTorrent(t, data, VersionV1, 32<<10, Profile{Name: "b"})
+	d, err := Diff(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.PieceRanges) != 0 || len(d.Notes) != 1 || !strings.Contains(d.Notes[0], "non comparables") {
+		t.Errorf("plages = %+v, notes = %q", d.PieceRanges, d.Notes)
+	}
+	if len(d.Files) != 0 {
+		t.Errorf("fichiers = %+v, attendu aucune différence", d.Files)
+	}
+}
+
+func TestDiffZeroPieceLength(t *testing.T) {
+	dir := t.TempDir()
+	data := filepath.Join(dir, "movie.mkv")
+	writeRandomFile(t, data, 1024, 1)
+	valid := createDiffTorrent(t, data, VersionV1, 16<<10, Profile{Name: "a"})
+	malformed := writeZeroPieceLengthTorrent(t, dir)
+
+	// Le torrent malformé est refusé avant toute division par la taille de pièce
+	for _, pair := range [][2]string{{valid, malformed}, {malformed, valid}} {
+		if _, err := Diff(pair[0], pair[1]); err == nil || !strings.Contains(err.Error(), "piece length") {
+			t.Errorf("Diff(%s, %s) error = %v, want piece length invalide", filepath.Base(pair[0]), filepath.Base(pair[1]), err)
+		}
+	}
+}
+
+func TestDiffSkipsPaddingPieces(t *testing.T) {
+	const pieceLength = 16 << 10
+	dir := t.TempDir()
+
+	// Deux torrents sans contenu commun, dont la deuxième pièce n'est que du bourrage
+	// (que des zéros, donc le même hash des deux côtés)
+	write := func(name string, seed int64) string {
+		t.Helper()
+		var pieces []byte
+		for _, data := range [][]byte{
+			writeRandomFile(t, filepath.Join(dir, name, "a"), pieceLength, seed),
+			make([]byte, pieceLength),
+			writeRandomFile(t, filepath.Join(dir, name, "b"), pieceLength, seed+1),
+		} {
+			sum := sha1.Sum(data)
+			pieces = append(pieces, sum[:]...)
+		}
+		infoBytes, err := bencode.Marshal(infoDict{
+			Name:        name,
+			PieceLength: pieceLength,
+			Pieces:      pieces,
+			Files: []fileEntry{
+				{Length: pieceLength, Path: []string{"a.mkv"}},
+				{Length: pieceLength, Path: []string{".pad", "16384"}, Attr: "p"},
+				{Length: pieceLength, Path: []string{"b.mkv"}},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		output := filepath.Join(dir, name+".torrent")
+		if err := NewGenerator().writeFile(infoBytes, nil, output); err != nil {
+			t.Fatal(err)
+		}
+		return output
+	}
+
+	d, err := Diff(write("a", 1), write("b", 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.IdenticalPieces != 0 || len(d.PieceRanges) != 0 {
+		t.Errorf("pièces identiques = %d, plages = %+v: le bourrage seul ne doit pas compter", d.IdenticalPieces, d.PieceRanges)
+	}
+}
+
+// diffStatuses résume les différences de fichiers sous la forme "chemin:statut ..."
+func diffStatuses(d *TorrentDiff) string {
+	parts := make([]string, len(d.Files))
+	for i, f := range d.Files {
+		parts[i] = f.Path + ":" + f.Status
+	}
+	return strings.Join(parts, " ")
+}
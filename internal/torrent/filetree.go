@@ -0,0 +1,101 @@
+package torrent
+
+import (
+	"sort"
+
+	"github.com/anacrolix/torrent/bencode"
+)
+
+// fileTreeFile contient les propriétés d'un fichier du file tree (BEP 52)
+type fileTreeFile struct {
+	Attr       string `bencode:"attr,omitempty"` // BEP 47
+	Length     int64  `bencode:"length"`
+	PiecesRoot []byte `bencode:"pieces root,omitempty"`
+}
+
+// fileTree représente le champ "file tree" d'un torrent v2: un dossier (Dir)
+// ou un fichier (File), stocké sous la clé vide
+type fileTree struct {
+	File *fileTreeFile
+	Dir  map[string]*fileTree
+}
+
+// add ajoute un fichier au chemin donné
+func (ft *fileTree) add(path []string, file fileTreeFile) {
+	node := ft
+	for _, part := range path {
+		if node.Dir == nil {
+			node.Dir = make(map[string]*fileTree)
+		}
+		child, ok := node.Dir[part]
+		if !ok {
+			child = &fileTree{}
+			node.Dir[part] = child
+		}
+		node = child
+	}
+	node.File = &file
+}
+
+// walk parcourt les fichiers de l'arbre dans l'ordre canonique (clés triées)
+func (ft *fileTree) walk(path []string, fn func(path []string, file fileTreeFile)) {
+	if ft.File != nil {
+		fn(path, *ft.File)
+		return
+	}
+
+	keys := make([]string, 0, len(ft.Dir))
+	for key := range ft.Dir {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		childPath := append(append([]string(nil), path...), key)
+		ft.Dir[key].walk(childPath, fn)
+	}
+}
+
+// MarshalBencode implémente bencode.Marshaler
+func (ft fileTree) MarshalBencode() ([]byte, error) {
+	if ft.File != nil {
+		return bencode.Marshal(map[string]fileTreeFile{"": *ft.File})
+	}
+
+	dir := make(map[string]bencode.Bytes, len(ft.Dir))
+	for key, child := range ft.Dir {
+		b, err := child.MarshalBencode()
+		if err != nil {
+			return nil, err
+		}
+		dir[key] = b
+	}
+	return bencode.Marshal(dir)
+}
+
+// UnmarshalBencode implémente bencode.Unmarshaler
+func (ft *fileTree) UnmarshalBencode(b []byte) error {
+	var dir map[string]bencode.Bytes
+	if err := bencode.Unmarshal(b, &dir); err != nil {
+		return err
+	}
+
+	if props, ok := dir[""]; ok {
+		var file fileTreeFile
+		if err := bencode.Unmarshal(props, &file); err != nil {
+			return err
+		}
+		ft.File = &file
+		return nil
+	}
+
+	ft.Dir = make(map[string]*fileTree, len(dir))
+	for key, childBytes := range dir {
+		child := &fileTree{}
+		if err := child.UnmarshalBencode(childBytes); err != nil {
+			return err
+		}
+		ft.Dir[key] = child
+	}
+	return nil
+}
@@ -1,8 +1,13 @@
 package torrent
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/anacrolix/torrent/bencode"
@@ -11,119 +16,552 @@ import (
 
 // Generator génère des fichiers torrent
 type Generator struct {
-	pieceSize int64
-	comment   string
-	createdBy string
+	pieceStrategy PieceStrategy
+	pieceSize     int64
+	targetPieces  int
+	minPieceSize  int64
+	maxPieceSize  int64
+	comment       string
+	createdBy     string
+	creationDate  *time.Time // date fixe (zéro = omise), nil pour la date de génération
+	reproducible  bool
+	padding       bool
+	padMinSize    int64
+	fileAttrs     bool
+	announceList  [][]string
+	source        string
+	private       bool
+	workers       int
+	progress      ProgressFunc
+	version       Version
+	webSeeds      []string
+	cache         *PieceCache
 }
 
 // NewGenerator crée un nouveau générateur de torrent
 func NewGenerator() *Generator {
 	return &Generator{
-		pieceSize: 256 * 1024, // 256 KB par défaut
-		comment:   "Created by Torrent All-In-One",
-		createdBy: "Torrent-AIO",
+		pieceStrategy: PieceAuto,
+		comment:       "Created by Torrent All-In-One",
+		createdBy:     "Torrent-AIO",
+		private:       true,
+		version:       VersionV1,
 	}
 }
 
-// SetPieceSize définit la taille des pièces
+// SetPieceSize impose une taille de pièce fixe (stratégie fixed)
 func (g *Generator) SetPieceSize(size int64) {
+	g.pieceStrategy = PieceFixed
 	g.pieceSize = size
 }
 
+// SetPieceStrategy définit comment la taille des pièces est choisie
+func (g *Generator) SetPieceStrategy(strategy PieceStrategy) {
+	g.pieceStrategy = strategy
+}
+
+// SetTargetPieces définit le nombre de pièces visé par la stratégie count
+func (g *Generator) SetTargetPieces(count int) {
+	g.targetPieces = count
+}
+
+// SetPieceSizeBounds définit les tailles de pièce minimale et maximale (0 = pas de limite)
+func (g *Generator) SetPieceSizeBounds(minSize, maxSize int64) {
+	g.minPieceSize = minSize
+	g.maxPieceSize = maxSize
+}
+
 // SetComment définit le commentaire du torrent
 func (g *Generator) SetComment(comment string) {
 	g.comment = comment
 }
 
+// SetCreatedBy définit le champ created by du torrent (vide pour l'omettre)
+func (g *Generator) SetCreatedBy(createdBy string) {
+	g.createdBy = createdBy
+}
+
+// SetCreationDate fixe la date de création du torrent; une date zéro omet le champ
+func (g *Generator) SetCreationDate(date time.Time) {
+	g.creationDate = &date
+}
+
+// SetReproducible active le mode reproductible: pour les mêmes données et paramètres,
+// le fichier torrent est identique octet pour octet (voir reproducible.go)
+func (g *Generator) SetReproducible(reproducible bool) {
+	g.reproducible = reproducible
+}
+
+// SetPadding active l'alignement des gros fichiers sur les pièces dans les torrents v1 multi-fichiers:
+// des fichiers de bourrage (BEP 47) entourent chaque fichier d'au moins minSize octets (0 = taille de pièce).
+// Les torrents v2 et hybrides sont toujours alignés.
+func (g *Generator) SetPadding(enabled bool, minSize int64) {
+	g.padding = enabled
+	g.padMinSize = minSize
+}
+
+// SetFileAttributes écrit les attributs BEP 47 des fichiers (h: caché, x: exécutable) même sans
+// bourrage. Ils font partie du dictionnaire info: par défaut, ils ne sont écrits qu'avec SetPadding
+// pour que l'infohash d'un torrent sans bourrage ne change pas.
+func (g *Generator) SetFileAttributes(enabled bool) {
+	g.fileAttrs = enabled
+}
+
+// SetSource définit le champ source du dictionnaire info (rend l'infohash propre au tracker)
+func (g *Generator) SetSource(source string) {
+	g.source = source
+}
+
+// SetPrivate définit le flag private (BEP 27)
+func (g *Generator) SetPrivate(private bool) {
+	g.private = private
+}
+
+// SetVersion définit le format du torrent (v1, v2 ou hybride)
+func (g *Generator) SetVersion(version Version) {
+	g.version = version
+}
+
+// SetWebSeeds définit les URLs de web seed du torrent (BEP 19)
+func (g *Generator) SetWebSeeds(urls []string) error {
+	for _, u := range urls {
+		if err := ValidateWebSeedURL(u); err != nil {
+			return err
+		}
+	}
+
+	g.webSeeds = append([]string(nil), urls...)
+	return nil
+}
+
+// SetWorkers définit le nombre de goroutines de hachage (0 = nombre de CPU)
+func (g *Generator) SetWorkers(workers int) {
+	g.workers = workers
+}
+
+// SetProgress définit la fonction appelée pour suivre l'avancement du hachage
+func (g *Generator) SetProgress(progress ProgressFunc) {
+	g.progress = progress
+}
+
+// SetCache définit le cache de hashes de pièces (v1) à utiliser, nil pour le désactiver
+func (g *Generator) SetCache(cache *PieceCache) {
+	g.cache = cache
+}
+
+// SetAnnounceList définit les trackers du torrent, regroupés par tiers (BEP 12)
+func (g *Generator) SetAnnounceList(tiers [][]string) error {
+	var list [][]string
+	for _, tier := range tiers {
+		if len(tier) == 0 {
+			continue
+		}
+		for _, announce := range tier {
+			if err := ValidateAnnounceURL(announce); err != nil {
+				return err
+			}
+		}
+		list = append(list, append([]string(nil), tier...))
+	}
+
+	g.announceList = list
+	return nil
+}
+
 // Create crée un fichier torrent à partir d'un fichier source
-func (g *Generator) Create(sourcePath, outputPath string) error {
+func (g *Generator) Create(ctx context.Context, sourcePath, outputPath string) error {
 	// Vérifier que le fichier source existe
-	info, err := os.Stat(sourcePath)
-	if err != nil {
+	if _, err := os.Stat(sourcePath); err != nil {
 		return fmt.Errorf("fichier source introuvable: %w", err)
 	}
 
-	// Calculer la taille optimale des pièces
-	pieceLength := g.calculatePieceLength(info.Size())
-
-	// Créer le metainfo
-	mi := metainfo.MetaInfo{
-		Comment:      g.comment,
-		CreatedBy:    g.createdBy,
-		CreationDate: time.Now().Unix(),
+	data, err := g.buildInfo(ctx, sourcePath)
+	if err != nil {
+		return err
 	}
 
-	// Construire les informations du fichier
-	builder := metainfo.Info{
-		PieceLength: pieceLength,
-		Private:     func() *bool { b := true; return &b }(),
+	return g.writeTorrent(data, outputPath)
+}
+
+// CreateFromDirectory crée un torrent à partir d'un dossier
+func (g *Generator) CreateFromDirectory(ctx context.Context, dirPath, outputPath string) error {
+	// Vérifier que le dossier existe
+	info, err := os.Stat(dirPath)
+	if err != nil {
+		return fmt.Errorf("dossier introuvable: %w", err)
 	}
 
-	if err := builder.BuildFromFilePath(sourcePath); err != nil {
-		return fmt.Errorf("erreur construction torrent: %w", err)
+	if !info.IsDir() {
+		return fmt.Errorf("%s n'est pas un dossier", dirPath)
 	}
 
-	mi.InfoBytes, err = bencode.Marshal(builder)
+	data, err := g.buildInfo(ctx, dirPath)
 	if err != nil {
-		return fmt.Errorf("erreur encodage info: %w", err)
+		return err
 	}
 
-	// Écrire le fichier torrent
-	f, err := os.Create(outputPath)
+	return g.writeTorrent(data, outputPath)
+}
+
+// CreateForProfiles crée un torrent par profil de tracker à partir d'une même source.
+// Les données ne sont lues qu'une seule fois, quels que soient les tailles de pièce et formats des profils.
+func (g *Generator) CreateForProfiles(ctx context.Context, sourcePath string, profiles []Profile, outputPaths []string) error {
+	if _, err := os.Stat(sourcePath); err != nil {
+		return fmt.Errorf("source introuvable: %w", err)
+	}
+
+	return g.createForProfiles(ctx, func() (string, []sourceFile, error) {
+		return collectFiles(sourcePath)
+	}, profiles, outputPaths)
+}
+
+// createForProfiles écrit un torrent par profil à partir des fichiers retournés par collect.
+// Chaque profil reçoit sa propre taille de pièce (dans ses limites) et son propre format; les
+// torrents de même taille de pièce et de même format partagent les mêmes pièces, et toutes les
+// pièces sont calculées en une seule lecture des données.
+func (g *Generator) createForProfiles(ctx context.Context, collect func() (string, []sourceFile, error), profiles []Profile, outputPaths []string) error {
+	if len(profiles) != len(outputPaths) {
+		return fmt.Errorf("nombre de profils (%d) et de fichiers de sortie (%d) différents", len(profiles), len(outputPaths))
+	}
+
+	name, files, err := collect()
 	if err != nil {
-		return fmt.Errorf("erreur création fichier: %w", err)
+		return err
 	}
-	defer f.Close()
+	name = g.prepareFiles(name, files)
 
-	if err := mi.Write(f); err != nil {
-		return fmt.Errorf("erreur écriture torrent: %w", err)
+	type planKey struct {
+		pieceLength int64
+		version     Version
+	}
+	byKey := make(map[planKey]*infoPlan)
+	var plans []*infoPlan
+	profilePlans := make([]*infoPlan, len(profiles))
+	generators := make([]Generator, len(profiles))
+	for i, profile := range profiles {
+		// Un profil invalide est refusé avant le hachage et l'écriture du premier torrent
+		generators[i] = *g
+		if err := generators[i].ApplyProfile(profile); err != nil {
+			return fmt.Errorf("profil %s: %w", profile.Name, err)
+		}
+
+		pieceLength, err := generators[i].choosePieceLength(streamLength(files))
+		if err != nil {
+			return fmt.Errorf("profil %s: %w", profile.Name, err)
+		}
+
+		key := planKey{pieceLength: pieceLength, version: g.version}
+		if profile.Version != "" {
+			key.version = profile.Version
+		}
+		plan, ok := byKey[key]
+		if !ok {
+			if plan, err = g.planInfo(name, files, key.pieceLength, key.version); err != nil {
+				return fmt.Errorf("profil %s: %w", profile.Name, err)
+			}
+			byKey[key] = plan
+			plans = append(plans, plan)
+		}
+		profilePlans[i] = plan
+	}
+
+	if err := g.hashPlans(ctx, files, plans); err != nil {
+		return err
+	}
+
+	results := make(map[*infoPlan]*torrentData, len(plans))
+	for _, plan := range plans {
+		results[plan] = plan.finish()
+	}
+	for i, pg := range generators {
+		if err := pg.writeTorrent(results[profilePlans[i]], outputPaths[i]); err != nil {
+			return fmt.Errorf("profil %s: %w", profiles[i].Name, err)
+		}
 	}
 
 	return nil
 }
 
-// CreateFromDirectory crée un torrent à partir d'un dossier
-func (g *Generator) CreateFromDirectory(dirPath, outputPath string) error {
-	// Vérifier que le dossier existe
-	info, err := os.Stat(dirPath)
+// torrentData contient le résultat du hachage, avant application des paramètres de tracker
+type torrentData struct {
+	info        infoDict
+	pieceLayers map[string]string
+}
+
+// buildInfo construit le dictionnaire info (liste des fichiers et pièces) d'un fichier ou dossier
+func (g *Generator) buildInfo(ctx context.Context, path string) (*torrentData, error) {
+	name, files, err := collectFiles(path)
 	if err != nil {
-		return fmt.Errorf("dossier introuvable: %w", err)
+		return nil, err
 	}
+	return g.buildFiles(ctx, name, files)
+}
 
-	if !info.IsDir() {
-		return fmt.Errorf("%s n'est pas un dossier", dirPath)
+// buildFiles construit le dictionnaire info à partir de fichiers triés dans l'ordre du torrent
+func (g *Generator) buildFiles(ctx context.Context, name string, files []sourceFile) (*torrentData, error) {
+	name = g.prepareFiles(name, files)
+
+	pieceLength, err := g.choosePieceLength(streamLength(files))
+	if err != nil {
+		return nil, err
 	}
 
-	// Calculer la taille totale pour la taille des pièces
-	totalSize, err := g.calculateDirSize(dirPath)
+	plan, err := g.planInfo(name, files, pieceLength, g.version)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if err := g.hashPlans(ctx, files, []*infoPlan{plan}); err != nil {
+		return nil, err
 	}
+	return plan.finish(), nil
+}
 
-	pieceLength := g.calculatePieceLength(totalSize)
+// infoPlan prépare le dictionnaire info d'un torrent pour une taille de pièce et un format donnés:
+// disposition des fichiers (bourrage compris) et calcul des pièces
+type infoPlan struct {
+	name        string
+	files       []sourceFile // fichiers du torrent, sans bourrage
+	layout      []sourceFile // flux haché, bourrage compris
+	single      bool         // torrent mono-fichier, sans chemin relatif
+	pieceLength int64
+	version     Version
+	v1          *v1Hasher
+	v2          *v2Hasher
+}
 
-	// Créer le metainfo
-	mi := metainfo.MetaInfo{
-		Comment:      g.comment,
-		CreatedBy:    g.createdBy,
-		CreationDate: time.Now().Unix(),
+// planInfo prépare le calcul des pièces de files pour une taille de pièce et un format
+func (g *Generator) planInfo(name string, files []sourceFile, pieceLength int64, version Version) (*infoPlan, error) {
+	p := &infoPlan{
+		name:        name,
+		files:       files,
+		layout:      files,
+		single:      len(files) == 1 && files[0].Parts == nil,
+		pieceLength: pieceLength,
+		version:     version,
+	}
+
+	switch version {
+	case VersionV2, VersionHybrid:
+		// Les pièces v2 sont alignées sur les fichiers: le bourrage n'est écrit que pour l'hybride
+		p.layout = alignFiles(files, pieceLength)
+		h, err := newV2Hasher(p.layout, pieceLength, version == VersionHybrid)
+		if err != nil {
+			return nil, fmt.Errorf("erreur construction torrent: %w", err)
+		}
+		p.v2 = h
+	default:
+		if g.padding && !p.single {
+			p.layout = padFiles(files, pieceLength, g.padMinSize)
+		}
+		if pieceLength <= 0 {
+			return nil, fmt.Errorf("erreur construction torrent: taille de pièce invalide: %d", pieceLength)
+		}
+		p.v1 = newV1Hasher(p.layout, pieceLength, g.cache)
 	}
+	return p, nil
+}
 
-	// Construire les informations du dossier
-	builder := metainfo.Info{
-		PieceLength: pieceLength,
-		Private:     func() *bool { b := true; return &b }(),
+// target retourne le flux de pièces à calculer pour ce plan
+func (p *infoPlan) target() *hashTarget {
+	if p.v2 != nil {
+		return &hashTarget{layout: p.layout, pieceLength: p.pieceLength, hash: p.v2.hash}
 	}
+	return &hashTarget{layout: p.layout, pieceLength: p.pieceLength, cached: p.v1.cachedFunc(), hash: p.v1.hash}
+}
 
-	if err := builder.BuildFromFilePath(dirPath); err != nil {
+// hashPlans calcule les pièces de tous les plans. Un seul plan est lu pièce par pièce, en sautant
+// les pièces déjà en cache; plusieurs plans partagent une seule lecture séquentielle des données.
+func (g *Generator) hashPlans(ctx context.Context, files []sourceFile, plans []*infoPlan) error {
+	var err error
+	if len(plans) == 1 {
+		t := plans[0].target()
+		err = readPieces(ctx, t.layout, t.pieceLength, g.workers, g.progress, t.cached, t.hash)
+	} else {
+		targets := make([]*hashTarget, len(plans))
+		for i, p := range plans {
+			targets[i] = p.target()
+		}
+		err = hashTargets(ctx, files, targets, g.workers, g.progress)
+	}
+
+	// Les pièces hachées sont conservées même en cas d'interruption, pour la prochaine tentative
+	if g.cache != nil {
+		if saveErr := g.cache.Save(); saveErr != nil && err == nil {
+			err = saveErr
+		}
+	}
+	if err != nil {
 		return fmt.Errorf("erreur construction torrent: %w", err)
 	}
+	return nil
+}
+
+// finish assemble le dictionnaire info une fois les pièces calculées
+func (p *infoPlan) finish() *torrentData {
+	data := &torrentData{
+		info: infoDict{
+			Name:        p.name,
+			PieceLength: p.pieceLength,
+		},
+	}
+	info := &data.info
+
+	if p.v2 == nil {
+		info.Pieces = p.v1.pieces
+		if p.single {
+			info.Length = p.files[0].Length
+			info.Attr = p.files[0].Attr
+		} else {
+			info.Files = fileEntries(p.layout)
+		}
+		return data
+	}
+
+	hashes := p.v2.finish()
+	info.MetaVersion = 2
+	info.FileTree = &fileTree{}
+	data.pieceLayers = make(map[string]string)
+	for i, f := range p.layout {
+		if f.Padding {
+			continue
+		}
+		treePath := f.Parts
+		if p.single {
+			treePath = []string{p.name}
+		}
+		info.FileTree.add(treePath, fileTreeFile{Attr: f.Attr, Length: f.Length, PiecesRoot: hashes.roots[i]})
+		if hashes.layers[i] != nil {
+			data.pieceLayers[string(hashes.roots[i])] = string(hashes.layers[i])
+		}
+	}
+
+	if p.version == VersionHybrid {
+		info.Pieces = hashes.pieces
+		if p.single {
+			info.Length = p.files[0].Length
+			info.Attr = p.files[0].Attr
+		} else {
+			info.Files = fileEntries(p.layout)
+		}
+	}
+	return data
+}
+
+// prepareFiles applique aux fichiers collectés les options qui modifient le dictionnaire info
+// (attributs BEP 47, mode reproductible) et retourne le nom du torrent
+func (g *Generator) prepareFiles(name string, files []sourceFile) string {
+	if !g.padding && !g.fileAttrs {
+		clearFileAttrs(files)
+	}
+	if g.reproducible {
+		name = canonicalFiles(name, files)
+	}
+	return name
+}
+
+// collectFiles liste les fichiers d'un fichier ou dossier dans l'ordre du torrent
+func collectFiles(root string) (string, []sourceFile, error) {
+	name := filepath.Base(root)
+	switch name {
+	case ".", "..", string(filepath.Separator):
+		name = metainfo.NoName
+	}
+
+	var files []sourceFile
+	err := filepath.Walk(root, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			// Les dossiers sont implicites dans les fichiers torrent
+			return nil
+		}
+		// Un lien (arborescence créée par Layout.Link) compte pour le fichier qu'il désigne
+		if fi, err = followLink(path, fi); err != nil {
+			return err
+		}
+
+		file := sourceFile{Path: path, Length: fi.Size(), Attr: fileAttr(fi.Name(), fi.Mode())}
+		if path != root {
+			relPath, err := filepath.Rel(root, path)
+			if err != nil {
+				return fmt.Errorf("erreur chemin relatif: %w", err)
+			}
+			file.Parts = strings.Split(filepath.ToSlash(relPath), "/")
+		}
+		files = append(files, file)
+		return nil
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("erreur parcours des fichiers: %w", err)
+	}
+
+	sortFiles(files)
+	return name, files, nil
+}
+
+// followLink retourne les informations du fichier désigné par un lien symbolique rencontré
+// pendant un parcours (fi vient de Lstat), fi sinon. Les liens vers des dossiers sont refusés.
+func followLink(path string, fi os.FileInfo) (os.FileInfo, error) {
+	if fi.Mode()&os.ModeSymlink == 0 {
+		return fi, nil
+	}
+	target, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("lien invalide %s: %w", path, err)
+	}
+	if target.IsDir() {
+		return nil, fmt.Errorf("lien vers un dossier non pris en charge: %s", path)
+	}
+	return target, nil
+}
+
+// sortFiles trie les fichiers dans l'ordre canonique: par composant de chemin, comme le file tree v2
+func sortFiles(files []sourceFile) {
+	sort.SliceStable(files, func(a, b int) bool {
+		return slices.Compare(files[a].Parts, files[b].Parts) < 0
+	})
+}
 
-	mi.InfoBytes, err = bencode.Marshal(builder)
+// fileEntries construit la liste files (v1) à partir des fichiers du torrent
+func fileEntries(files []sourceFile) []fileEntry {
+	entries := make([]fileEntry, 0, len(files))
+	for _, f := range files {
+		entry := fileEntry{Attr: f.Attr, Length: f.Length, Path: f.Parts}
+		if f.Padding {
+			entry.Attr = attrPadding
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// writeTorrent applique les paramètres du générateur au dictionnaire info et écrit le fichier torrent
+func (g *Generator) writeTorrent(data *torrentData, outputPath string) error {
+	// Le flag private et la source font partie du dictionnaire info (et donc de l'infohash)
+	info := data.info
+	if g.private {
+		private := true
+		info.Private = &private
+	}
+	info.Source = g.source
+
+	infoBytes, err := bencode.Marshal(info)
 	if err != nil {
 		return fmt.Errorf("erreur encodage info: %w", err)
 	}
 
+	return g.writeFile(infoBytes, data.pieceLayers, outputPath)
+}
+
+// writeFile écrit le fichier torrent à partir d'un dictionnaire info déjà encodé
+func (g *Generator) writeFile(infoBytes []byte, pieceLayers map[string]string, outputPath string) error {
+	tf := torrentFile{
+		MetaInfo:    g.newMetaInfo(),
+		PieceLayers: pieceLayers,
+	}
+	tf.InfoBytes = infoBytes
+
 	// Écrire le fichier torrent
 	f, err := os.Create(outputPath)
 	if err != nil {
@@ -131,14 +569,34 @@ func (g *Generator) CreateFromDirectory(dirPath, outputPath string) error {
 	}
 	defer f.Close()
 
-	if err := mi.Write(f); err != nil {
+	if err := bencode.NewEncoder(f).Encode(tf); err != nil {
 		return fmt.Errorf("erreur écriture torrent: %w", err)
 	}
 
 	return nil
 }
 
-// calculatePieceLength calcule la taille optimale des pièces
+// newMetaInfo prépare le metainfo avec les champs communs (trackers, commentaire, date)
+func (g *Generator) newMetaInfo() metainfo.MetaInfo {
+	mi := metainfo.MetaInfo{
+		Comment:      g.comment,
+		CreatedBy:    g.createdBy,
+		CreationDate: g.creationTimestamp(),
+		UrlList:      append(metainfo.UrlList(nil), g.webSeeds...),
+	}
+
+	if len(g.announceList) > 0 {
+		mi.Announce = g.announceList[0][0]
+		// announce-list n'est utile que s'il y a plus d'un tracker
+		if len(g.announceList) > 1 || len(g.announceList[0]) > 1 {
+			mi.AnnounceList = metainfo.AnnounceList(g.announceList).Clone()
+		}
+	}
+
+	return mi
+}
+
+// calculatePieceLength calcule la taille optimale des pièces (stratégie auto)
 func (g *Generator) calculatePieceLength(fileSize int64) int64 {
 	// Taille en Mo
 	const (
@@ -146,11 +604,15 @@ func (g *Generator) calculatePieceLength(fileSize int64) int64 {
 		GB2  = int64(2 * 1024 * 1024 * 1024)
 		GB4  = int64(4 * 1024 * 1024 * 1024)
 		GB8  = int64(8 * 1024 * 1024 * 1024)
+		GB16 = int64(16 * 1024 * 1024 * 1024)
+		GB64 = int64(64 * 1024 * 1024 * 1024)
 		MB1  = int64(1024 * 1024)
 		MB2  = int64(2 * 1024 * 1024)
 		MB4  = int64(4 * 1024 * 1024)
 		MB8  = int64(8 * 1024 * 1024)
 		MB16 = int64(16 * 1024 * 1024)
+		MB32 = int64(32 * 1024 * 1024)
+		MB64 = int64(64 * 1024 * 1024)
 	)
 
 	switch {
@@ -162,47 +624,31 @@ func (g *Generator) calculatePieceLength(fileSize int64) int64 {
 		return MB4
 	case fileSize <= GB8:
 		return MB8
-	default:
+	case fileSize <= GB16:
 		return MB16
+	case fileSize <= GB64:
+		return MB32
+	default:
+		return MB64
 	}
 }
 
-// calculateDirSize calcule la taille totale d'un dossier
-func (g *Generator) calculateDirSize(path string) (int64, error) {
-	var size int64
-
-	entries, err := os.ReadDir(path)
+// GetInfoHash retourne les infohashes (v1 et/ou v2) d'un fichier torrent existant
+func GetInfoHash(torrentPath string) (InfoHashes, error) {
+	mi, err := metainfo.LoadFromFile(torrentPath)
 	if err != nil {
-		return 0, err
-	}
-
-	for _, entry := range entries {
-		info, err := entry.Info()
-		if err != nil {
-			continue
-		}
-
-		if entry.IsDir() {
-			subSize, err := g.calculateDirSize(path + "/" + entry.Name())
-			if err != nil {
-				continue
-			}
-			size += subSize
-		} else {
-			size += info.Size()
-		}
+		return InfoHashes{}, fmt.Errorf("erreur lecture torrent: %w", err)
 	}
 
-	return size, nil
+	return computeInfoHashes(mi.InfoBytes)
 }
 
-// GetInfoHash retourne le hash info d'un fichier torrent existant
-func GetInfoHash(torrentPath string) (string, error) {
+// GetTrackers retourne les trackers d'un fichier torrent existant, regroupés par tiers
+func GetTrackers(torrentPath string) ([][]string, error) {
 	mi, err := metainfo.LoadFromFile(torrentPath)
 	if err != nil {
-		return "", fmt.Errorf("erreur lecture torrent: %w", err)
+		return nil, fmt.Errorf("erreur lecture torrent: %w", err)
 	}
 
-	hash := mi.HashInfoBytes()
-	return hash.HexString(), nil
+	return mi.UpvertedAnnounceList(), nil
 }
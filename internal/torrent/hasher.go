@@ -0,0 +1,326 @@
+package torrent
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sync"
+)
+
+// ProgressFunc est appelée pendant le hachage avec le nombre d'octets traités et le total
+type ProgressFunc func(hashed, total int64)
+
+// sourceFile représente un fichier de données du torrent, dans l'ordre du torrent
+type sourceFile struct {
+	Path    string
+	Length  int64
+	Parts   []string // chemin dans le torrent
+	Padding bool     // fichier de bourrage (BEP 47), lu comme des zéros
+	Attr    string   // autres attributs BEP 47 (h: caché, x: exécutable)
+	Lenient bool     // données absentes ou tronquées lues comme des zéros (vérification)
+}
+
+// pieceJob représente une pièce lue en attente de hachage
+type pieceJob struct {
+	index int
+	data  []byte
+}
+
+// streamLength retourne la taille totale des données (bourrage compris)
+func streamLength(files []sourceFile) int64 {
+	var total int64
+	for _, f := range files {
+		total += f.Length
+	}
+	return total
+}
+
+// v1Hasher calcule les pièces SHA-1 d'un flux de fichiers, pièce par pièce
+type v1Hasher struct {
+	pieces []byte
+	lookup *cacheLookup // nil sans cache
+}
+
+// newV1Hasher prépare le calcul des pièces v1 de files, en consultant cache s'il n'est pas nil
+func newV1Hasher(files []sourceFile, pieceLength int64, cache *PieceCache) *v1Hasher {
+	numPieces := int((streamLength(files) + pieceLength - 1) / pieceLength)
+	h := &v1Hasher{pieces: make([]byte, numPieces*sha1.Size)}
+	if cache != nil {
+		h.lookup = cache.lookup(files, pieceLength)
+	}
+	return h
+}
+
+// cachedFunc retourne la fonction indiquant si une pièce est déjà connue du cache (nil sans cache).
+// Une pièce connue est recopiée dans le résultat.
+func (h *v1Hasher) cachedFunc() func(index int) bool {
+	if h.lookup == nil {
+		return nil
+	}
+	return func(index int) bool {
+		return h.lookup.get(index, h.pieces[index*sha1.Size:(index+1)*sha1.Size])
+	}
+}
+
+// hash calcule le SHA-1 d'une pièce et l'enregistre dans le cache
+func (h *v1Hasher) hash(index int, data []byte) {
+	sum := sha1.Sum(data)
+	copy(h.pieces[index*sha1.Size:], sum[:])
+	if h.lookup != nil {
+		h.lookup.put(index, sum)
+	}
+}
+
+// readPieces lit séquentiellement le flux des fichiers par pièces de pieceLength octets
+// et confie chaque pièce à la fonction hash, appelée en parallèle sur un pool de workers.
+// Les pièces pour lesquelles cached (optionnelle) retourne true ne sont pas lues.
+func readPieces(ctx context.Context, files []sourceFile, pieceLength int64, workers int, progress ProgressFunc, cached func(index int) bool, hash func(index int, data []byte)) error {
+	if pieceLength <= 0 {
+		return fmt.Errorf("taille de pièce invalide: %d", pieceLength)
+	}
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	total := streamLength(files)
+	numPieces := int((total + pieceLength - 1) / pieceLength)
+	if numPieces == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Les buffers sont recyclés pour borner la mémoire à (workers + 1) pièces
+	maxBuffers := workers + 1
+	buffers := make(chan []byte, maxBuffers)
+	allocated := 0
+
+	jobs := make(chan pieceJob, workers)
+
+	var (
+		wg         sync.WaitGroup
+		progressMu sync.Mutex
+		hashed     int64
+	)
+
+	advance := func(n int64) {
+		progressMu.Lock()
+		hashed += n
+		if progress != nil {
+			progress(hashed, total)
+		}
+		progressMu.Unlock()
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				hash(job.index, job.data)
+				advance(int64(len(job.data)))
+
+				buffers <- job.data[:cap(job.data)]
+			}
+		}()
+	}
+
+	readErr := func() error {
+		defer close(jobs)
+
+		r := newFilesReader(files)
+		defer r.Close()
+
+		seek := false
+		for index := 0; index < numPieces; index++ {
+			if cached != nil && cached(index) {
+				advance(min(pieceLength, total-int64(index)*pieceLength))
+				seek = true
+				continue
+			}
+			if seek {
+				if err := r.skipTo(int64(index) * pieceLength); err != nil {
+					return err
+				}
+				seek = false
+			}
+
+			var buf []byte
+			if allocated < maxBuffers {
+				select {
+				case buf = <-buffers:
+				default:
+					buf = make([]byte, pieceLength)
+					allocated++
+				}
+			} else {
+				select {
+				case buf = <-buffers:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			n, err := io.ReadFull(r, buf)
+			if err != nil && err != io.ErrUnexpectedEOF {
+				if err == io.EOF {
+					return fmt.Errorf("fin des données inattendue à la pièce %d", index)
+				}
+				return err
+			}
+			// Seule la dernière pièce peut être incomplète
+			if int64(n) < pieceLength && index != numPieces-1 {
+				return fmt.Errorf("fin des données inattendue à la pièce %d", index)
+			}
+
+			select {
+			case jobs <- pieceJob{index: index, data: buf[:n]}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	}()
+
+	wg.Wait()
+
+	return readErr
+}
+
+// filesReader lit une liste de fichiers comme un flux continu, en vérifiant leur taille
+type filesReader struct {
+	files     []sourceFile
+	index     int
+	current   *os.File
+	padding   bool
+	remaining int64
+}
+
+func newFilesReader(files []sourceFile) *filesReader {
+	return &filesReader{files: files}
+}
+
+// Read implémente io.Reader
+func (r *filesReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil && !r.padding {
+			if r.index >= len(r.files) {
+				return 0, io.EOF
+			}
+			if r.files[r.index].Padding {
+				r.padding = true
+				r.remaining = r.files[r.index].Length
+				continue
+			}
+			r.remaining = r.files[r.index].Length
+			f, err := os.Open(r.files[r.index].Path)
+			if err != nil {
+				if r.files[r.index].Lenient {
+					r.padding = true
+					continue
+				}
+				return 0, fmt.Errorf("erreur ouverture fichier: %w", err)
+			}
+			r.current = f
+		}
+
+		if r.remaining == 0 {
+			if r.current != nil {
+				r.current.Close()
+				r.current = nil
+			}
+			r.padding = false
+			r.index++
+			continue
+		}
+
+		if int64(len(p)) > r.remaining {
+			p = p[:r.remaining]
+		}
+
+		if r.padding {
+			clear(p)
+			r.remaining -= int64(len(p))
+			return len(p), nil
+		}
+
+		n, err := r.current.Read(p)
+		r.remaining -= int64(n)
+		if err == io.EOF && r.remaining > 0 {
+			if r.files[r.index].Lenient {
+				r.current.Close()
+				r.current = nil
+				r.padding = true
+				if n > 0 {
+					return n, nil
+				}
+				continue
+			}
+			return n, fmt.Errorf("%s: fichier plus court que prévu", r.files[r.index].Path)
+		}
+		if err != nil && err != io.EOF {
+			return n, err
+		}
+		if n > 0 {
+			return n, nil
+		}
+	}
+}
+
+// skipTo positionne le lecteur à un offset absolu du flux, sans lire les données qui précèdent
+func (r *filesReader) skipTo(offset int64) error {
+	r.Close()
+	r.padding = false
+
+	var start int64
+	for r.index = 0; r.index < len(r.files); r.index++ {
+		if offset < start+r.files[r.index].Length {
+			break
+		}
+		start += r.files[r.index].Length
+	}
+	if r.index >= len(r.files) {
+		return nil
+	}
+
+	file := r.files[r.index]
+	pos := offset - start
+	r.remaining = file.Length - pos
+	if file.Padding {
+		r.padding = true
+		return nil
+	}
+
+	f, err := os.Open(file.Path)
+	if err != nil {
+		if file.Lenient {
+			r.padding = true
+			return nil
+		}
+		return fmt.Errorf("erreur ouverture fichier: %w", err)
+	}
+	if _, err := f.Seek(pos, io.SeekStart); err != nil {
+		f.Close()
+		return fmt.Errorf("erreur positionnement fichier: %w", err)
+	}
+	r.current = f
+	return nil
+}
+
+// Close ferme le fichier en cours de lecture
+func (r *filesReader) Close() error {
+	if r.current != nil {
+		err := r.current.Close()
+		r.current = nil
+		return err
+	}
+	return nil
+}
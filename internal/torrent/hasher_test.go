@@ -0,0 +1,153 @@
+package torrent
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/anacrolix/torrent/metainfo"
+)
+
+// writeRandomFile crée un fichier de données pseudo-aléatoires reproductibles
+func writeRandomFile(t *testing.T, path string, size int, seed int64) []byte {
+	t.Helper()
+	data := make([]byte, size)
+	rand.New(rand.NewSource(seed)).Read(data)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// readV1Pieces calcule les pièces v1 de files avec readPieces, comme la génération d'une cible
+// unique, puis enregistre le cache s'il n'est pas nil
+func readV1Pieces(ctx context.Context, files []sourceFile, pieceLength int64, workers int, progress ProgressFunc, cache *PieceCache) ([]byte, error) {
+	h := newV1Hasher(files, pieceLength, cache)
+	err := readPieces(ctx, files, pieceLength, workers, progress, h.cachedFunc(), h.hash)
+	if cache != nil {
+		if saveErr := cache.Save(); saveErr != nil && err == nil {
+			err = saveErr
+		}
+	}
+	return h.pieces, err
+}
+
+func TestReadPiecesMatchesSequential(t *testing.T) {
+	dir := t.TempDir()
+	const pieceLength = 16 * 1024
+
+	tests := []struct {
+		name  string
+		sizes []int
+	}{
+		{name: "Fichier unique aligné", sizes: []int{4 * pieceLength}},
+		{name: "Fichier unique avec dernière pièce partielle", sizes: []int{3*pieceLength + 123}},
+		{name: "Plusieurs fichiers à cheval sur les pièces", sizes: []int{pieceLength + 7, 5, 2*pieceLength - 1, 0, 999}},
+		{name: "Fichier plus petit qu'une pièce", sizes: []int{42}},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var files []sourceFile
+			var all []byte
+			for j, size := range tt.sizes {
+				path := filepath.Join(dir, tt.name, string(rune('a'+j)))
+				all = append(all, writeRandomFile(t, path, size, int64(i*10+j))...)
+				files = append(files, sourceFile{Path: path, Length: int64(size)})
+			}
+
+			want, err := metainfo.GeneratePieces(bytes.NewReader(all), pieceLength, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var last int64
+			got, err := readV1Pieces(context.Background(), files, pieceLength, 4, func(hashed, total int64) {
+				if hashed < last || hashed > total {
+					t.Errorf("progression incohérente: %d après %d (total %d)", hashed, last, total)
+				}
+				last = hashed
+			}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(got, want) {
+				t.Errorf("readPieces() diffère du hachage séquentiel")
+			}
+			if last != int64(len(all)) {
+				t.Errorf("progression finale = %d, want %d", last, len(all))
+			}
+		})
+	}
+}
+
+func TestReadPiecesCancel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data")
+	writeRandomFile(t, path, 1<<20, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := readV1Pieces(ctx, []sourceFile{{Path: path, Length: 1 << 20}}, 16*1024, 2, nil, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("readPieces() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestReadPiecesShortFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data")
+	writeRandomFile(t, path, 1000, 1)
+
+	_, err := readV1Pieces(context.Background(), []sourceFile{{Path: path, Length: 2000}}, 16*1024, 2, nil, nil)
+	if err == nil {
+		t.Error("readPieces() devrait échouer sur un fichier tronqué")
+	}
+}
+
+func TestCreateMatchesBuildFromFilePath(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "Release")
+	writeRandomFile(t, filepath.Join(root, "movie.mkv"), 300000, 1)
+	writeRandomFile(t, filepath.Join(root, "Subs", "fr.srt"), 1234, 2)
+	writeRandomFile(t, filepath.Join(root, "release.nfo"), 567, 3)
+
+	g := NewGenerator()
+	out := filepath.Join(dir, "release.torrent")
+	if err := g.CreateFromDirectory(context.Background(), root, out); err != nil {
+		t.Fatal(err)
+	}
+
+	mi, err := metainfo.LoadFromFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := mi.UnmarshalInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := metainfo.Info{PieceLength: got.PieceLength}
+	if err := want.BuildFromFilePath(root); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got.Pieces, want.Pieces) {
+		t.Error("pièces différentes de metainfo.Info.BuildFromFilePath")
+	}
+	if len(got.Files) != len(want.Files) {
+		t.Fatalf("%d fichiers, want %d", len(got.Files), len(want.Files))
+	}
+	for i := range want.Files {
+		if got.Files[i].DisplayPath(&got) != want.Files[i].DisplayPath(&want) {
+			t.Errorf("fichier %d = %s, want %s", i, got.Files[i].DisplayPath(&got), want.Files[i].DisplayPath(&want))
+		}
+	}
+}
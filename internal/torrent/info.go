@@ -0,0 +1,150 @@
+package torrent
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/anacrolix/torrent/bencode"
+	"github.com/anacrolix/torrent/metainfo"
+)
+
+// Version représente le format de torrent à générer
+type Version string
+
+const (
+	// VersionV1 génère un torrent BitTorrent v1 classique (BEP 3)
+	VersionV1 Version = "v1"
+	// VersionV2 génère un torrent BitTorrent v2 (BEP 52)
+	VersionV2 Version = "v2"
+	// VersionHybrid génère un torrent hybride v1+v2
+	VersionHybrid Version = "hybrid"
+)
+
+// ParseVersion convertit une chaîne (v1, v2, hybrid) en Version
+func ParseVersion(s string) (Version, error) {
+	switch v := Version(strings.ToLower(strings.TrimSpace(s))); v {
+	case "", VersionV1:
+		return VersionV1, nil
+	case VersionV2, VersionHybrid:
+		return v, nil
+	default:
+		return "", fmt.Errorf("version de torrent inconnue: %s (v1, v2 ou hybrid)", s)
+	}
+}
+
+// infoDict est le dictionnaire info tel qu'écrit dans le torrent (v1, v2 ou hybride).
+// metainfo.Info ne connaît ni le file tree v2 ni les attributs de fichiers (BEP 47).
+type infoDict struct {
+	Attr        string      `bencode:"attr,omitempty"`         // BEP 47 (torrent mono-fichier v1)
+	FileTree    *fileTree   `bencode:"file tree,omitempty"`    // BEP 52
+	Files       []fileEntry `bencode:"files,omitempty"`        // BEP 3
+	Length      int64       `bencode:"length,omitempty"`       // BEP 3
+	MetaVersion int64       `bencode:"meta version,omitempty"` // BEP 52
+	Name        string      `bencode:"name"`                   // BEP 3
+	PieceLength int64       `bencode:"piece length"`           // BEP 3
+	Pieces      []byte      `bencode:"pieces,omitempty"`       // BEP 3
+	Private     *bool       `bencode:"private,omitempty"`      // BEP 27
+	Source      string      `bencode:"source,omitempty"`
+}
+
+// fileEntry représente un fichier de la liste files (v1)
+type fileEntry struct {
+	Attr   string   `bencode:"attr,omitempty"` // BEP 47
+	Length int64    `bencode:"length"`
+	Path   []string `bencode:"path"`
+}
+
+// torrentFile représente un fichier .torrent complet, avec les piece layers v2
+type torrentFile struct {
+	metainfo.MetaInfo
+	PieceLayers map[string]string `bencode:"piece layers,omitempty"` // BEP 52
+}
+
+// InfoHashes regroupe les infohashes d'un torrent
+type InfoHashes struct {
+	V1 string `json:"v1,omitempty"` // SHA-1 du dictionnaire info (torrents v1 et hybrides)
+	V2 string `json:"v2,omitempty"` // SHA-256 du dictionnaire info (torrents v2 et hybrides)
+}
+
+// String retourne les infohashes disponibles
+func (h InfoHashes) String() string {
+	switch {
+	case h.V1 != "" && h.V2 != "":
+		return fmt.Sprintf("v1: %s, v2: %s", h.V1, h.V2)
+	case h.V2 != "":
+		return h.V2
+	default:
+		return h.V1
+	}
+}
+
+// computeInfoHashes calcule les infohashes v1 et/ou v2 d'un dictionnaire info bencodé
+func computeInfoHashes(infoBytes []byte) (InfoHashes, error) {
+	var info struct {
+		MetaVersion int64  `bencode:"meta version"`
+		Pieces      []byte `bencode:"pieces"`
+	}
+	if err := bencode.Unmarshal(infoBytes, &info); err != nil {
+		return InfoHashes{}, fmt.Errorf("erreur décodage info: %w", err)
+	}
+
+	var hashes InfoHashes
+	if info.MetaVersion != 2 || len(info.Pieces) > 0 {
+		sum := sha1.Sum(infoBytes)
+		hashes.V1 = hex.EncodeToString(sum[:])
+	}
+	if info.MetaVersion == 2 {
+		sum := sha256.Sum256(infoBytes)
+		hashes.V2 = hex.EncodeToString(sum[:])
+	}
+	return hashes, nil
+}
+
+// loadTorrentFile charge un fichier .torrent, piece layers v2 comprises
+func loadTorrentFile(torrentPath string) (*torrentFile, error) {
+	data, err := os.ReadFile(torrentPath)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lecture torrent: %w", err)
+	}
+
+	var tf torrentFile
+	if err := bencode.Unmarshal(data, &tf); err != nil {
+		return nil, fmt.Errorf("erreur lecture torrent: %w", err)
+	}
+
+	return &tf, nil
+}
+
+// isV1 indique si le dictionnaire info contient des pièces v1 (torrents v1 et hybrides)
+func (info *infoDict) isV1() bool {
+	return len(info.Pieces) > 0 || info.MetaVersion != 2
+}
+
+// version retourne le format du torrent d'après son dictionnaire info
+func (info *infoDict) version() Version {
+	switch {
+	case info.MetaVersion == 2 && len(info.Pieces) > 0:
+		return VersionHybrid
+	case info.MetaVersion == 2:
+		return VersionV2
+	default:
+		return VersionV1
+	}
+}
+
+// unmarshalInfo décode le dictionnaire info d'un torrent. Une taille de pièce absente ou
+// négative est refusée: tous les calculs de pièces divisent par elle.
+func (tf *torrentFile) unmarshalInfo() (infoDict, error) {
+	var info infoDict
+	if err := bencode.Unmarshal(tf.InfoBytes, &info); err != nil {
+		return infoDict{}, fmt.Errorf("erreur décodage info: %w", err)
+	}
+	if info.PieceLength <= 0 {
+		return infoDict{}, fmt.Errorf("torrent invalide: piece length = %d", info.PieceLength)
+	}
+	return info, nil
+}
@@ -0,0 +1,198 @@
+package torrent
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"fmt"
+	"path"
+	"time"
+)
+
+const (
+	// minPieceCount est le nombre de pièces en dessous duquel la taille de pièce est jugée trop grande
+	minPieceCount = 50
+	// maxPieceCount est le nombre de pièces au-delà duquel le fichier torrent devient trop lourd
+	maxPieceCount = 50000
+)
+
+// InspectedFile décrit un fichier listé dans un torrent
+type InspectedFile struct {
+	Path    string `json:"path"`
+	Length  int64  `json:"length"`
+	Padding bool   `json:"padding,omitempty"`
+	Attr    string `json:"attr,omitempty"` // attributs BEP 47 hors bourrage (h: caché, x: exécutable)
+}
+
+// Inspection contient les métadonnées d'un fichier torrent et les anomalies détectées
+type Inspection struct {
+	Torrent      string          `json:"torrent"`
+	Name         string          `json:"name"`
+	Version      Version         `json:"version"`
+	InfoHash     InfoHashes      `json:"info_hash"`
+	TotalSize    int64           `json:"total_size"`
+	PieceLength  int64           `json:"piece_length"`
+	Pieces       int             `json:"pieces"`
+	Private      bool            `json:"private"`
+	Source       string          `json:"source,omitempty"`
+	Comment      string          `json:"comment,omitempty"`
+	CreatedBy    string          `json:"created_by,omitempty"`
+	CreationDate *time.Time      `json:"creation_date,omitempty"`
+	Trackers     [][]string      `json:"trackers"`
+	WebSeeds     []string        `json:"web_seeds,omitempty"`
+	Files        []InspectedFile `json:"files"`
+	Anomalies    []string        `json:"anomalies"`
+}
+
+// Inspect lit un fichier torrent et signale les anomalies courantes
+// (absence de tracker, nombre de pièces extrême, pièces incohérentes...)
+func Inspect(torrentPath string) (*Inspection, error) {
+	tf, err := loadTorrentFile(torrentPath)
+	if err != nil {
+		return nil, err
+	}
+	info, err := tf.unmarshalInfo()
+	if err != nil {
+		return nil, err
+	}
+	return inspectInfo(torrentPath, tf, &info)
+}
+
+// inspectInfo construit l'inspection d'un torrent déjà chargé et décodé
+func inspectInfo(torrentPath string, tf *torrentFile, info *infoDict) (*Inspection, error) {
+	hashes, err := computeInfoHashes(tf.InfoBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Inspection{
+		Torrent:     torrentPath,
+		Name:        info.Name,
+		InfoHash:    hashes,
+		TotalSize:   info.totalLength(),
+		PieceLength: info.PieceLength,
+		Private:     info.Private != nil && *info.Private,
+		Source:      info.Source,
+		Comment:     tf.Comment,
+		CreatedBy:   tf.CreatedBy,
+		Trackers:    tf.UpvertedAnnounceList(),
+		WebSeeds:    tf.UrlList,
+		Anomalies:   []string{},
+	}
+	if r.Trackers == nil {
+		r.Trackers = [][]string{}
+	}
+	if tf.CreationDate != 0 {
+		date := time.Unix(tf.CreationDate, 0)
+		r.CreationDate = &date
+	}
+
+	r.Version = info.version()
+
+	if !info.isV1() && info.FileTree == nil {
+		r.Anomalies = append(r.Anomalies, "dictionnaire info incomplet (file tree absent)")
+		return r, nil
+	}
+
+	files, trees := info.torrentFiles("")
+	for _, f := range files {
+		// Le bourrage v2 est implicite: seul le bourrage v1 figure dans le torrent
+		if f.Padding && !info.isV1() {
+			continue
+		}
+		p := path.Join(f.Parts...)
+		if f.Parts == nil {
+			p = info.Name
+		}
+		r.Files = append(r.Files, InspectedFile{Path: p, Length: f.Length, Padding: f.Padding, Attr: f.Attr})
+	}
+
+	// Nombre de pièces attendu d'après le flux de données (bourrage compris)
+	expected := int((streamLength(files) + info.PieceLength - 1) / info.PieceLength)
+	r.Pieces = expected
+	if info.isV1() {
+		r.Pieces = len(info.Pieces) / sha1.Size
+		if len(info.Pieces)%sha1.Size != 0 || r.Pieces != expected {
+			r.Anomalies = append(r.Anomalies, fmt.Sprintf("nombre de pièces incohérent: %d hashes pour %d pièces attendues", r.Pieces, expected))
+		}
+	}
+
+	r.Anomalies = append(r.Anomalies, inspectAnomalies(r, tf, trees)...)
+	if info.isV1() {
+		r.Anomalies = append(r.Anomalies, paddingAnomalies(files, info.PieceLength)...)
+	}
+	return r, nil
+}
+
+// inspectAnomalies signale les problèmes qui n'empêchent pas la lecture du torrent
+func inspectAnomalies(r *Inspection, tf *torrentFile, trees []fileTreeFile) []string {
+	var anomalies []string
+
+	if len(r.Trackers) == 0 {
+		if r.Private {
+			anomalies = append(anomalies, "aucun tracker: un torrent privé ne pourra pas trouver de pairs")
+		} else {
+			anomalies = append(anomalies, "aucun tracker (announce absent)")
+		}
+	}
+	for _, tier := range r.Trackers {
+		for _, announce := range tier {
+			if err := ValidateAnnounceURL(announce); err != nil {
+				anomalies = append(anomalies, err.Error())
+			}
+		}
+	}
+
+	if r.PieceLength < blockSize || r.PieceLength&(r.PieceLength-1) != 0 {
+		anomalies = append(anomalies, fmt.Sprintf("taille de pièce %d non standard (puissance de 2 d'au moins 16 KiB attendue)", r.PieceLength))
+	}
+	if r.Pieces < minPieceCount {
+		anomalies = append(anomalies, fmt.Sprintf("très peu de pièces (%d): taille de pièce %s trop grande pour %d octets", r.Pieces, formatSize(r.PieceLength), r.TotalSize))
+	}
+	if r.Pieces > maxPieceCount {
+		anomalies = append(anomalies, fmt.Sprintf("beaucoup de pièces (%d): taille de pièce %s trop petite, fichier torrent volumineux", r.Pieces, formatSize(r.PieceLength)))
+	}
+	if len(r.Files) == 0 || r.TotalSize == 0 {
+		anomalies = append(anomalies, "torrent vide")
+	}
+
+	// Les fichiers v2 de plus d'une pièce doivent avoir leur couche de pièces
+	missingLayers := 0
+	for _, tree := range trees {
+		if tree.Length <= r.PieceLength {
+			continue
+		}
+		layer, ok := tf.PieceLayers[string(tree.PiecesRoot)]
+		pieces := (tree.Length + r.PieceLength - 1) / r.PieceLength
+		if !ok || int64(len(layer)) != pieces*sha256.Size {
+			missingLayers++
+		}
+	}
+	if missingLayers > 0 {
+		anomalies = append(anomalies, fmt.Sprintf("piece layers manquants ou invalides pour %d fichier(s)", missingLayers))
+	}
+
+	if r.CreationDate != nil && r.CreationDate.After(time.Now().Add(24*time.Hour)) {
+		anomalies = append(anomalies, "date de création dans le futur")
+	}
+
+	return anomalies
+}
+
+// paddingAnomalies vérifie que chaque fichier de bourrage (BEP 47) se termine sur une frontière de pièce
+func paddingAnomalies(files []sourceFile, pieceLength int64) []string {
+	var anomalies []string
+	var offset int64
+	for i, f := range files {
+		offset += f.Length
+		if !f.Padding {
+			continue
+		}
+		switch {
+		case offset%pieceLength != 0:
+			anomalies = append(anomalies, fmt.Sprintf("fichier de bourrage %s mal aligné: se termine à l'octet %d, hors frontière de pièce", path.Join(f.Parts...), offset))
+		case i == len(files)-1:
+			anomalies = append(anomalies, "fichier de bourrage en fin de torrent (inutile)")
+		}
+	}
+	return anomalies
+}
@@ -0,0 +1,89 @@
+package torrent
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInspectReferenceTorrents(t *testing.T) {
+	tests := []struct {
+		file        string
+		version     Version
+		pieceLength int64
+		pieces      int
+		files       int
+	}{
+		{file: "bittorrent-v2-test.torrent", version: VersionV2, pieceLength: 4 * 1024 * 1024},
+		{file: "bittorrent-v2-hybrid-test.torrent", version: VersionHybrid, pieceLength: 512 * 1024, pieces: 1715},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			r, err := Inspect(filepath.Join("testdata", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.Version != tt.version || r.PieceLength != tt.pieceLength {
+				t.Errorf("version = %s, piece length = %d", r.Version, r.PieceLength)
+			}
+			if tt.pieces > 0 && r.Pieces != tt.pieces {
+				t.Errorf("pieces = %d, want %d", r.Pieces, tt.pieces)
+			}
+			if r.CreationDate == nil || r.CreatedBy != "libtorrent" {
+				t.Errorf("creation date = %v, created by = %q", r.CreationDate, r.CreatedBy)
+			}
+
+			var total int64
+			for _, f := range r.Files {
+				if !f.Padding {
+					total += f.Length
+				}
+			}
+			if total != r.TotalSize {
+				t.Errorf("somme des fichiers = %d, total = %d", total, r.TotalSize)
+			}
+
+			// Les torrents de référence n'ont pas de tracker
+			if len(r.Anomalies) != 1 || !strings.Contains(r.Anomalies[0], "aucun tracker") {
+				t.Errorf("anomalies = %v", r.Anomalies)
+			}
+		})
+	}
+}
+
+func TestInspectAnomalies(t *testing.T) {
+	dir := t.TempDir()
+	data := filepath.Join(dir, "Release")
+	writeRandomFile(t, filepath.Join(data, "a.mkv"), 300000, 1)
+	writeRandomFile(t, filepath.Join(data, "b.nfo"), 100, 2)
+
+	g := NewGenerator()
+	g.SetVersion(VersionHybrid)
+	if err := g.ApplyProfile(Profile{Name: "a", AnnounceList: [][]string{{"https://a.example/announce"}}, Source: "A", Private: true}); err != nil {
+		t.Fatal(err)
+	}
+	torrentPath := filepath.Join(dir, "release.torrent")
+	if err := g.Create(context.Background(), data, torrentPath); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := Inspect(torrentPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !r.Private || r.Source != "A" || len(r.Trackers) != 1 {
+		t.Errorf("private = %t, source = %q, trackers = %v", r.Private, r.Source, r.Trackers)
+	}
+	if len(r.Files) != 3 || !r.Files[1].Padding || r.Files[2].Path != "b.nfo" {
+		t.Errorf("files = %+v", r.Files)
+	}
+	if r.Pieces != 2 {
+		t.Errorf("pieces = %d, want 2", r.Pieces)
+	}
+	if len(r.Anomalies) != 1 || !strings.Contains(r.Anomalies[0], "très peu de pièces") {
+		t.Errorf("anomalies = %v", r.Anomalies)
+	}
+}
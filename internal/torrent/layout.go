@@ -0,0 +1,86 @@
+package torrent
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// resolveDataRoot détermine l'emplacement des données d'un torrent à partir d'un chemin
+// pouvant désigner les données elles-mêmes ou le dossier qui les contient
+func resolveDataRoot(info *infoDict, dataPath string) string {
+	stat, err := os.Stat(dataPath)
+	if err != nil || !stat.IsDir() {
+		return dataPath
+	}
+
+	candidate := filepath.Join(dataPath, info.Name)
+	if info.isSingleFile() {
+		return candidate
+	}
+	if stat, err := os.Stat(candidate); err == nil && stat.IsDir() {
+		return candidate
+	}
+	return dataPath
+}
+
+// isSingleFile indique si le torrent ne contient qu'un seul fichier sans dossier
+func (info *infoDict) isSingleFile() bool {
+	if info.isV1() {
+		return len(info.Files) == 0
+	}
+	if info.FileTree == nil || info.FileTree.File != nil || len(info.FileTree.Dir) != 1 {
+		return false
+	}
+	child, ok := info.FileTree.Dir[info.Name]
+	return ok && child.File != nil
+}
+
+// torrentFiles retourne les fichiers d'un torrent dans l'ordre du flux de données,
+// bourrage compris, avec leur chemin sur disque sous root.
+// Pour un torrent v2 sans pièces v1, les fichiers sont alignés sur les pièces (voir alignFiles)
+// et trees contient les propriétés v2 de chaque fichier.
+func (info *infoDict) torrentFiles(root string) (files []sourceFile, trees []fileTreeFile) {
+	single := info.isSingleFile()
+
+	if info.isV1() {
+		if single {
+			return []sourceFile{{Path: root, Length: info.Length, Attr: info.Attr}}, nil
+		}
+		for _, f := range info.Files {
+			file := sourceFile{Length: f.Length, Parts: f.Path}
+			if f.isPadding() {
+				file.Padding = true
+			} else {
+				file.Attr = f.Attr
+				file.Path = filepath.Join(append([]string{root}, f.Path...)...)
+			}
+			files = append(files, file)
+		}
+		return files, nil
+	}
+
+	var treeFiles []fileTreeFile
+	info.FileTree.walk(nil, func(path []string, file fileTreeFile) {
+		f := sourceFile{Length: file.Length, Parts: path, Attr: file.Attr}
+		if single {
+			f.Path = root
+			f.Parts = nil
+		} else {
+			f.Path = filepath.Join(append([]string{root}, path...)...)
+		}
+		files = append(files, f)
+		treeFiles = append(treeFiles, file)
+	})
+
+	// Aligner les propriétés v2 sur la liste avec bourrage
+	aligned := alignFiles(files, info.PieceLength)
+	trees = make([]fileTreeFile, len(aligned))
+	next := 0
+	for i, f := range aligned {
+		if !f.Padding {
+			trees[i] = treeFiles[next]
+			next++
+		}
+	}
+	return aligned, trees
+}
@@ -0,0 +1,78 @@
+package torrent
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/anacrolix/torrent/bencode"
+	"github.com/anacrolix/torrent/metainfo"
+)
+
+// MagnetURI construit le lien magnet d'un torrent: infohash v1 (btih) et/ou v2 (btmh),
+// nom affiché, trackers et taille exacte des données. Les trackers d'un torrent privé sont omis:
+// leurs URLs portent la passkey du membre, et un lien magnet est fait pour être partagé.
+func MagnetURI(mi *metainfo.MetaInfo) (string, error) {
+	hashes, err := computeInfoHashes(mi.InfoBytes)
+	if err != nil {
+		return "", err
+	}
+
+	var info infoDict
+	if err := bencode.Unmarshal(mi.InfoBytes, &info); err != nil {
+		return "", fmt.Errorf("erreur décodage info: %w", err)
+	}
+
+	var params []string
+	if hashes.V1 != "" {
+		params = append(params, "xt=urn:btih:"+hashes.V1)
+	}
+	if hashes.V2 != "" {
+		// Multihash: 0x12 (SHA-256), 0x20 (32 octets)
+		params = append(params, "xt=urn:btmh:1220"+hashes.V2)
+	}
+	if info.Name != "" {
+		params = append(params, "dn="+url.QueryEscape(info.Name))
+	}
+	params = append(params, "xl="+strconv.FormatInt(info.totalLength(), 10))
+	if info.Private == nil || !*info.Private {
+		for _, tracker := range mi.UpvertedAnnounceList().DistinctValues() {
+			params = append(params, "tr="+url.QueryEscape(tracker))
+		}
+	}
+
+	return "magnet:?" + strings.Join(params, "&"), nil
+}
+
+// GetMagnet retourne le lien magnet d'un fichier torrent existant
+func GetMagnet(torrentPath string) (string, error) {
+	mi, err := metainfo.LoadFromFile(torrentPath)
+	if err != nil {
+		return "", fmt.Errorf("erreur lecture torrent: %w", err)
+	}
+
+	return MagnetURI(mi)
+}
+
+// totalLength retourne la taille des données du torrent, hors fichiers de bourrage
+func (info *infoDict) totalLength() int64 {
+	var total int64
+
+	switch {
+	case info.isV1() && len(info.Files) > 0:
+		for _, f := range info.Files {
+			if !f.isPadding() {
+				total += f.Length
+			}
+		}
+	case info.isV1():
+		total = info.Length
+	case info.FileTree != nil:
+		info.FileTree.walk(nil, func(_ []string, f fileTreeFile) {
+			total += f.Length
+		})
+	}
+
+	return total
+}
@@ -0,0 +1,109 @@
+package torrent
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGetMagnetReferenceTorrents(t *testing.T) {
+	tests := []struct {
+		file string
+		want []string
+	}{
+		{
+			file: "bittorrent-v2-test.torrent",
+			want: []string{
+				"xt=urn:btmh:1220caf1e1c30e81cb361b9ee167c4aa64228a7fa4fa9f6105232b28ad099f3a302e",
+				"dn=bittorrent-v2-test",
+			},
+		},
+		{
+			file: "bittorrent-v2-hybrid-test.torrent",
+			want: []string{
+				"xt=urn:btih:631a31dd0a46257d5078c0dee4e66e26f73e42ac",
+				"xt=urn:btmh:1220d8dd32ac93357c368556af3ac1d95c9d76bd0dff6fa9833ecdac3d53134efabb",
+				"dn=bittorrent-v1-v2-hybrid-test",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			magnet, err := GetMagnet(filepath.Join("testdata", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, param := range tt.want {
+				if !strings.Contains(magnet, param) {
+					t.Errorf("%s ne contient pas %s", magnet, param)
+				}
+			}
+		})
+	}
+}
+
+func TestGetMagnet(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "Movie 2020.mkv")
+	if err := os.WriteFile(path, make([]byte, 123456), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	g := NewGenerator()
+	g.SetVersion(VersionHybrid)
+	g.SetPrivate(false)
+	if err := g.SetAnnounceList([][]string{{"https://tracker.example/announce?passkey=abc"}, {"udp://backup.example:6969/announce"}}); err != nil {
+		t.Fatal(err)
+	}
+	torrentPath := filepath.Join(dir, "movie.torrent")
+	if err := g.Create(context.Background(), path, torrentPath); err != nil {
+		t.Fatal(err)
+	}
+
+	magnet, err := GetMagnet(torrentPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashes, err := GetInfoHash(torrentPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u, err := url.Parse(magnet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+
+	wantXT := []string{"urn:btih:" + hashes.V1, "urn:btmh:1220" + hashes.V2}
+	if strings.Join(q["xt"], " ") != strings.Join(wantXT, " ") {
+		t.Errorf("xt = %v, want %v", q["xt"], wantXT)
+	}
+	if q.Get("dn") != "Movie 2020.mkv" {
+		t.Errorf("dn = %q", q.Get("dn"))
+	}
+	if q.Get("xl") != "123456" {
+		t.Errorf("xl = %q, want 123456", q.Get("xl"))
+	}
+	wantTR := []string{"https://tracker.example/announce?passkey=abc", "udp://backup.example:6969/announce"}
+	if strings.Join(q["tr"], " ") != strings.Join(wantTR, " ") {
+		t.Errorf("tr = %v, want %v", q["tr"], wantTR)
+	}
+
+	// Torrent privé: la passkey du tracker ne doit pas se retrouver dans le lien
+	g.SetPrivate(true)
+	if err := g.Create(context.Background(), path, torrentPath); err != nil {
+		t.Fatal(err)
+	}
+	magnet, err = GetMagnet(torrentPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(magnet, "tr=") || strings.Contains(magnet, "passkey") {
+		t.Errorf("magnet d'un torrent privé avec trackers: %s", magnet)
+	}
+}
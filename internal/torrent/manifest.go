@@ -0,0 +1,300 @@
+package torrent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Un manifeste de disposition décrit un torrent multi-fichiers virtuel: chaque chemin du torrent
+// pointe vers un fichier quelconque sur disque. La vidéo peut ainsi rester dans la médiathèque
+// pendant que le NFO et les sous-titres sont écrits dans le dossier de sortie, sans copie.
+// Le client de seed a besoin d'une arborescence réelle: Link la reconstitue avec des liens.
+//
+//	name: Film.2024.1080p.WEB.H264-GROUPE
+//	files:
+//	  - path: Film.2024.1080p.WEB.H264-GROUPE.mkv
+//	    source: /media/films/Film (2024)/film.mkv
+//	  - path: Film.2024.1080p.WEB.H264-GROUPE.nfo
+//	    source: Film.2024.1080p.WEB.H264-GROUPE.nfo
+//	  - path: Subs
+//	    source: /media/films/Film (2024)/subs
+
+// Layout est un manifeste de disposition: nom du torrent et correspondance chemin -> source
+type Layout struct {
+	Name  string       `yaml:"name" json:"name"`
+	Files []LayoutFile `yaml:"files" json:"files"`
+}
+
+// LayoutFile associe un chemin du torrent (séparateur /) à un fichier ou dossier sur disque.
+// Une source relative est résolue depuis le dossier du manifeste. Un dossier source est
+// ajouté récursivement sous le chemin indiqué.
+type LayoutFile struct {
+	Path   string `yaml:"path" json:"path"`
+	Source string `yaml:"source" json:"source"`
+}
+
+// LinkMode choisit le type de lien utilisé pour reconstituer l'arborescence d'un manifeste
+type LinkMode string
+
+const (
+	// LinkHard crée des liens physiques (même système de fichiers que les sources)
+	LinkHard LinkMode = "hardlink"
+	// LinkSymbolic crée des liens symboliques vers les chemins absolus des sources
+	LinkSymbolic LinkMode = "symlink"
+)
+
+// ParseLinkMode convertit un type de lien de la configuration (hardlink par défaut)
+func ParseLinkMode(s string) (LinkMode, error) {
+	switch LinkMode(strings.ToLower(strings.TrimSpace(s))) {
+	case "", LinkHard:
+		return LinkHard, nil
+	case LinkSymbolic:
+		return LinkSymbolic, nil
+	default:
+		return "", fmt.Errorf("type de lien invalide: %q (hardlink ou symlink)", s)
+	}
+}
+
+// LoadLayout lit un manifeste de disposition YAML ou JSON. Les sources relatives sont
+// résolues depuis le dossier du manifeste.
+func LoadLayout(manifestPath string) (*Layout, error) {
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lecture manifeste: %w", err)
+	}
+
+	var layout Layout
+	// JSON est un sous-ensemble de YAML: un seul décodeur pour les deux formats
+	if err := yaml.Unmarshal(data, &layout); err != nil {
+		return nil, fmt.Errorf("erreur lecture manifeste %s: %w", manifestPath, err)
+	}
+
+	base, err := filepath.Abs(filepath.Dir(manifestPath))
+	if err != nil {
+		return nil, fmt.Errorf("chemin invalide: %w", err)
+	}
+	for i, f := range layout.Files {
+		if f.Source != "" && !filepath.IsAbs(f.Source) {
+			layout.Files[i].Source = filepath.Join(base, f.Source)
+		}
+	}
+
+	if err := layout.Validate(); err != nil {
+		return nil, fmt.Errorf("manifeste %s: %w", manifestPath, err)
+	}
+	return &layout, nil
+}
+
+// Save écrit le manifeste au format YAML
+func (l *Layout) Save(manifestPath string) error {
+	data, err := yaml.Marshal(l)
+	if err != nil {
+		return fmt.Errorf("erreur encodage manifeste: %w", err)
+	}
+	if err := os.WriteFile(manifestPath, data, 0644); err != nil {
+		return fmt.Errorf("erreur écriture manifeste: %w", err)
+	}
+	return nil
+}
+
+// Validate vérifie le nom et les chemins du manifeste, sans accéder au disque
+func (l *Layout) Validate() error {
+	if err := checkLayoutName(l.Name); err != nil {
+		return err
+	}
+	if len(l.Files) == 0 {
+		return fmt.Errorf("aucun fichier")
+	}
+
+	seen := make(map[string]bool)
+	for _, f := range l.Files {
+		if f.Source == "" {
+			return fmt.Errorf("%s: source manquante", f.Path)
+		}
+		if _, err := layoutParts(f.Path); err != nil {
+			return err
+		}
+		clean := path.Clean(f.Path)
+		if seen[clean] {
+			return fmt.Errorf("chemin en double: %s", clean)
+		}
+		seen[clean] = true
+	}
+	return nil
+}
+
+// checkLayoutName vérifie que le nom du torrent est un nom de dossier valide
+func checkLayoutName(name string) error {
+	switch {
+	case strings.TrimSpace(name) == "":
+		return fmt.Errorf("nom du torrent manquant")
+	case name == "." || name == ".." || strings.ContainsAny(name, `/\`):
+		return fmt.Errorf("nom du torrent invalide: %q", name)
+	}
+	return nil
+}
+
+// layoutParts découpe un chemin du torrent en refusant les chemins absolus ou sortant du torrent
+func layoutParts(p string) ([]string, error) {
+	if p == "" || strings.HasPrefix(p, "/") || strings.Contains(p, `\`) {
+		return nil, fmt.Errorf("chemin invalide: %q (chemin relatif au torrent, séparateur /)", p)
+	}
+	parts := strings.Split(path.Clean(p), "/")
+	for _, part := range parts {
+		if part == "." || part == ".." {
+			return nil, fmt.Errorf("chemin invalide: %q", p)
+		}
+	}
+	return parts, nil
+}
+
+// sourceFiles résout les sources du manifeste et retourne les fichiers dans l'ordre du torrent
+func (l *Layout) sourceFiles() (string, []sourceFile, error) {
+	if err := l.Validate(); err != nil {
+		return "", nil, err
+	}
+
+	var files []sourceFile
+	owners := make(map[string]string) // chemin dans le torrent -> entrée du manifeste
+	add := func(entry string, parts []string, source string, fi os.FileInfo) error {
+		key := strings.Join(parts, "/")
+		if other, ok := owners[key]; ok {
+			return fmt.Errorf("%s: déjà fourni par l'entrée %s", key, other)
+		}
+		owners[key] = entry
+		files = append(files, sourceFile{
+			Path:   source,
+			Length: fi.Size(),
+			Parts:  parts,
+			Attr:   fileAttr(parts[len(parts)-1], fi.Mode()),
+		})
+		return nil
+	}
+
+	for _, f := range l.Files {
+		parts, _ := layoutParts(f.Path)
+		// Les liens symboliques sont suivis: la source peut elle-même être un lien
+		fi, err := os.Stat(f.Source)
+		if err != nil {
+			return "", nil, fmt.Errorf("source introuvable pour %s: %w", f.Path, err)
+		}
+		if !fi.IsDir() {
+			if err := add(f.Path, parts, f.Source, fi); err != nil {
+				return "", nil, err
+			}
+			continue
+		}
+
+		err = filepath.Walk(f.Source, func(p string, fi os.FileInfo, err error) error {
+			if err != nil || fi.IsDir() {
+				return err
+			}
+			// Walk ne suit pas les liens: la taille est celle du fichier désigné
+			if fi, err = followLink(p, fi); err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(f.Source, p)
+			if err != nil {
+				return fmt.Errorf("erreur chemin relatif: %w", err)
+			}
+			sub := append(append([]string{}, parts...), strings.Split(filepath.ToSlash(rel), "/")...)
+			return add(f.Path, sub, p, fi)
+		})
+		if err != nil {
+			return "", nil, fmt.Errorf("erreur parcours de %s: %w", f.Source, err)
+		}
+	}
+
+	// Un fichier ne peut pas être aussi un dossier du torrent (ex: "Subs" et "Subs/fr.srt")
+	for key, entry := range owners {
+		for dir := path.Dir(key); dir != "."; dir = path.Dir(dir) {
+			if _, ok := owners[dir]; ok {
+				return "", nil, fmt.Errorf("%s (entrée %s) est à la fois un fichier et un dossier", dir, entry)
+			}
+		}
+	}
+
+	sortFiles(files)
+	return l.Name, files, nil
+}
+
+// CreateFromLayout crée un torrent multi-fichiers à partir d'un manifeste de disposition,
+// en lisant chaque fichier à son emplacement d'origine
+func (g *Generator) CreateFromLayout(ctx context.Context, layout *Layout, outputPath string) error {
+	name, files, err := layout.sourceFiles()
+	if err != nil {
+		return err
+	}
+
+	data, err := g.buildFiles(ctx, name, files)
+	if err != nil {
+		return err
+	}
+
+	return g.writeTorrent(data, outputPath)
+}
+
+// CreateLayoutForProfiles crée un torrent par profil de tracker à partir d'un manifeste de disposition
+func (g *Generator) CreateLayoutForProfiles(ctx context.Context, layout *Layout, profiles []Profile, outputPaths []string) error {
+	return g.createForProfiles(ctx, layout.sourceFiles, profiles, outputPaths)
+}
+
+// Link reconstitue l'arborescence du torrent dans destDir/<nom> avec des liens vers les sources,
+// pour qu'un client BitTorrent puisse seeder les données. Les liens déjà en place sont conservés.
+// Retourne le dossier créé.
+func (l *Layout) Link(destDir string, mode LinkMode) (string, error) {
+	_, files, err := l.sourceFiles()
+	if err != nil {
+		return "", err
+	}
+
+	root := filepath.Join(destDir, l.Name)
+	for _, f := range files {
+		target := filepath.Join(append([]string{root}, f.Parts...)...)
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return "", fmt.Errorf("erreur création dossier: %w", err)
+		}
+		if err := linkFile(f.Path, target, mode); err != nil {
+			return "", err
+		}
+	}
+	return root, nil
+}
+
+// linkFile crée target comme lien vers source, sauf s'il désigne déjà le même fichier
+func linkFile(source, target string, mode LinkMode) error {
+	if existing, err := os.Stat(target); err == nil {
+		if src, err := os.Stat(source); err == nil && os.SameFile(existing, src) {
+			return nil
+		}
+		return fmt.Errorf("%s existe déjà et ne correspond pas à %s", target, source)
+	}
+
+	switch mode {
+	case LinkSymbolic:
+		abs, err := filepath.Abs(source)
+		if err != nil {
+			return fmt.Errorf("chemin invalide: %w", err)
+		}
+		if err := os.Symlink(abs, target); err != nil {
+			return fmt.Errorf("erreur création lien symbolique: %w", err)
+		}
+	default:
+		if err := os.Link(source, target); err != nil {
+			var linkErr *os.LinkError
+			if errors.As(err, &linkErr) && errors.Is(linkErr.Err, syscall.EXDEV) {
+				return fmt.Errorf("erreur création lien physique %s: source sur un autre système de fichiers (utiliser des liens symboliques)", target)
+			}
+			return fmt.Errorf("erreur création lien physique: %w", err)
+		}
+	}
+	return nil
+}
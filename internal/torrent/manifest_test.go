@@ -0,0 +1,235 @@
+package torrent
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeScatteredRelease écrit une release dispersée: vidéo dans la médiathèque, NFO dans le
+// dossier de sortie, sous-titres dans un dossier à part. Retourne le manifeste correspondant.
+func writeScatteredRelease(t *testing.T, base string) *Layout {
+	t.Helper()
+
+	video := filepath.Join(base, "media", "Film (2024)", "film.mkv")
+	nfo := filepath.Join(base, "out", "Film.2024-GRP.nfo")
+	subs := filepath.Join(base, "media", "Film (2024)", "subs")
+	writeRandomFile(t, video, 300000, 1)
+	writeRandomFile(t, nfo, 1500, 2)
+	writeRandomFile(t, filepath.Join(subs, "fr.srt"), 4000, 3)
+	writeRandomFile(t, filepath.Join(subs, "en.srt"), 3800, 4)
+
+	return &Layout{
+		Name: "Film.2024-GRP",
+		Files: []LayoutFile{
+			{Path: "Film.2024-GRP.mkv", Source: video},
+			{Path: "Film.2024-GRP.nfo", Source: nfo},
+			{Path: "Subs", Source: subs},
+		},
+	}
+}
+
+func TestCreateFromLayout(t *testing.T) {
+	for _, version := range []Version{VersionV1, VersionV2, VersionHybrid} {
+		for _, mode := range []LinkMode{LinkHard, LinkSymbolic} {
+			t.Run(string(version)+"/"+string(mode), func(t *testing.T) {
+				base := t.TempDir()
+				layout := writeScatteredRelease(t, base)
+
+				newGenerator := func() *Generator {
+					g := NewGenerator()
+					g.SetVersion(version)
+					g.SetPieceSize(16 << 10)
+					g.SetCreationDate(time.Time{})
+					return g
+				}
+
+				fromLayout := filepath.Join(base, "layout.torrent")
+				if err := newGenerator().CreateFromLayout(context.Background(), layout, fromLayout); err != nil {
+					t.Fatal(err)
+				}
+
+				// L'arborescence reconstituée donne exactement le même torrent
+				root, err := layout.Link(filepath.Join(base, "seed"), mode)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if root != filepath.Join(base, "seed", "Film.2024-GRP") {
+					t.Errorf("dossier créé = %s", root)
+				}
+				fromTree := filepath.Join(base, "tree.torrent")
+				if err := newGenerator().CreateFromDirectory(context.Background(), root, fromTree); err != nil {
+					t.Fatal(err)
+				}
+				a, _ := os.ReadFile(fromLayout)
+				b, _ := os.ReadFile(fromTree)
+				if !bytes.Equal(a, b) {
+					t.Error("le torrent du manifeste diffère de celui de l'arborescence reconstituée")
+				}
+
+				result, err := Verify(context.Background(), fromLayout, root, 2, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !result.OK() {
+					t.Errorf("vérification en échec: %+v", result.Files)
+				}
+
+				// Relancer Link conserve les liens déjà en place
+				if _, err := layout.Link(filepath.Join(base, "seed"), mode); err != nil {
+					t.Errorf("second Link: %v", err)
+				}
+			})
+		}
+	}
+}
+
+func TestCreateLayoutForProfiles(t *testing.T) {
+	base := t.TempDir()
+	layout := writeScatteredRelease(t, base)
+
+	profiles := []Profile{{Name: "a", Source: "A"}, {Name: "b", Source: "B"}}
+	outputs := []string{filepath.Join(base, "a.torrent"), filepath.Join(base, "b.torrent")}
+	if err := NewGenerator().CreateLayoutForProfiles(context.Background(), layout, profiles, outputs); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := Diff(outputs[0], outputs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Files) != 0 || d.IdenticalFiles != 4 || len(d.Fields) != 1 || d.Fields[0].Field != "source" {
+		t.Errorf("les torrents des profils doivent ne différer que par la source: %+v %+v", d.Fields, d.Files)
+	}
+}
+
+func TestLoadLayout(t *testing.T) {
+	dir := t.TempDir()
+	writeRandomFile(t, filepath.Join(dir, "film.mkv"), 1000, 1)
+
+	yamlManifest := filepath.Join(dir, "layout.yml")
+	os.WriteFile(yamlManifest, []byte("name: Film\nfiles:\n  - path: Film.mkv\n    source: film.mkv\n"), 0644)
+	jsonManifest := filepath.Join(dir, "layout.json")
+	os.WriteFile(jsonManifest, []byte(`{"name": "Film", "files": [{"path": "Film.mkv", "source": "film.mkv"}]}`), 0644)
+
+	for _, manifest := range []string{yamlManifest, jsonManifest} {
+		layout, err := LoadLayout(manifest)
+		if err != nil {
+			t.Fatalf("%s: %v", manifest, err)
+		}
+		// Les sources relatives sont résolues depuis le dossier du manifeste
+		if layout.Files[0].Source != filepath.Join(dir, "film.mkv") {
+			t.Errorf("%s: source = %s", manifest, layout.Files[0].Source)
+		}
+	}
+
+	// Save puis LoadLayout redonne le même manifeste
+	layout, _ := LoadLayout(yamlManifest)
+	saved := filepath.Join(t.TempDir(), "saved.yml")
+	if err := layout.Save(saved); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := LoadLayout(saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.Name != layout.Name || reloaded.Files[0] != layout.Files[0] {
+		t.Errorf("manifeste relu = %+v, attendu %+v", reloaded, layout)
+	}
+}
+
+func TestLayoutSourceSymlinks(t *testing.T) {
+	dir := t.TempDir()
+	video := filepath.Join(dir, "film.mkv")
+	writeRandomFile(t, video, 5000, 1)
+	subs := filepath.Join(dir, "subs")
+	writeRandomFile(t, filepath.Join(subs, "fr.srt"), 100, 2)
+	if err := os.Symlink(video, filepath.Join(subs, "bonus.mkv")); err != nil {
+		t.Skip("liens symboliques non pris en charge:", err)
+	}
+
+	layout := &Layout{Name: "Film", Files: []LayoutFile{{Path: "Subs", Source: subs}}}
+	_, files, err := layout.sourceFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, f := range files {
+		if filepath.Base(f.Path) == "bonus.mkv" {
+			found = true
+			if f.Length != 5000 {
+				t.Errorf("taille du lien = %d, attendu celle du fichier désigné (5000)", f.Length)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("lien absent des fichiers: %+v", files)
+	}
+
+	// Un lien vers un dossier dans une source est refusé, comme dans CreateFromDirectory
+	writeRandomFile(t, filepath.Join(dir, "extras", "a.srt"), 100, 3)
+	os.Symlink(filepath.Join(dir, "extras"), filepath.Join(subs, "extras"))
+	err = NewGenerator().CreateFromLayout(context.Background(), layout, filepath.Join(dir, "out.torrent"))
+	if err == nil || !strings.Contains(err.Error(), "lien vers un dossier") {
+		t.Errorf("erreur = %v, un lien vers un dossier doit être refusé", err)
+	}
+}
+
+func TestLayoutInvalid(t *testing.T) {
+	dir := t.TempDir()
+	video := filepath.Join(dir, "film.mkv")
+	writeRandomFile(t, video, 1000, 1)
+	writeRandomFile(t, filepath.Join(dir, "subs", "fr.srt"), 100, 2)
+
+	tests := []struct {
+		name   string
+		layout Layout
+		want   string
+	}{
+		{"sans nom", Layout{Files: []LayoutFile{{Path: "a.mkv", Source: video}}}, "nom du torrent manquant"},
+		{"nom avec dossier", Layout{Name: "a/b", Files: []LayoutFile{{Path: "a.mkv", Source: video}}}, "nom du torrent invalide"},
+		{"sans fichier", Layout{Name: "Film"}, "aucun fichier"},
+		{"chemin absolu", Layout{Name: "Film", Files: []LayoutFile{{Path: "/a.mkv", Source: video}}}, "chemin invalide"},
+		{"sortie du torrent", Layout{Name: "Film", Files: []LayoutFile{{Path: "../a.mkv", Source: video}}}, "chemin invalide"},
+		{"doublon", Layout{Name: "Film", Files: []LayoutFile{{Path: "a.mkv", Source: video}, {Path: "./a.mkv", Source: video}}}, "en double"},
+		{"source absente", Layout{Name: "Film", Files: []LayoutFile{{Path: "a.mkv", Source: filepath.Join(dir, "absent.mkv")}}}, "source introuvable"},
+		{"fichier et dossier", Layout{Name: "Film", Files: []LayoutFile{{Path: "Subs", Source: video}, {Path: "Subs/en.srt", Source: video}}}, "à la fois un fichier et un dossier"},
+		{"dossier recouvrant un fichier", Layout{Name: "Film", Files: []LayoutFile{{Path: "Subs", Source: filepath.Join(dir, "subs")}, {Path: "Subs/fr.srt", Source: video}}}, "déjà fourni"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewGenerator().CreateFromLayout(context.Background(), &tt.layout, filepath.Join(dir, "out.torrent"))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("erreur = %v, attendu %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinkExistingFile(t *testing.T) {
+	dir := t.TempDir()
+	video := filepath.Join(dir, "film.mkv")
+	writeRandomFile(t, video, 1000, 1)
+	writeRandomFile(t, filepath.Join(dir, "seed", "Film", "Film.mkv"), 1000, 2)
+
+	layout := &Layout{Name: "Film", Files: []LayoutFile{{Path: "Film.mkv", Source: video}}}
+	if _, err := layout.Link(filepath.Join(dir, "seed"), LinkHard); err == nil || !strings.Contains(err.Error(), "existe déjà") {
+		t.Errorf("erreur = %v, un fichier différent ne doit pas être écrasé", err)
+	}
+}
+
+func TestParseLinkMode(t *testing.T) {
+	for in, want := range map[string]LinkMode{"": LinkHard, "hardlink": LinkHard, "Symlink": LinkSymbolic} {
+		if got, err := ParseLinkMode(in); err != nil || got != want {
+			t.Errorf("ParseLinkMode(%q) = %s, %v", in, got, err)
+		}
+	}
+	if _, err := ParseLinkMode("copy"); err == nil {
+		t.Error("ParseLinkMode(copy) doit échouer")
+	}
+}
@@ -0,0 +1,68 @@
+package torrent
+
+import (
+	"crypto/sha256"
+	"math/bits"
+)
+
+// blockSize est la taille des blocs feuilles des arbres de merkle BitTorrent v2 (BEP 52)
+const blockSize = 16 * 1024
+
+// merkleRoot calcule la racine d'un arbre de merkle SHA-256 binaire.
+// Le nombre de feuilles est complété jusqu'à la puissance de deux suivante avec pad.
+func merkleRoot(hashes [][sha256.Size]byte, pad [sha256.Size]byte) [sha256.Size]byte {
+	if len(hashes) == 0 {
+		return [sha256.Size]byte{}
+	}
+
+	layer := make([][sha256.Size]byte, nextPowerOfTwo(len(hashes)))
+	copy(layer, hashes)
+	for i := len(hashes); i < len(layer); i++ {
+		layer[i] = pad
+	}
+
+	var buf [2 * sha256.Size]byte
+	for len(layer) > 1 {
+		for i := 0; i < len(layer)/2; i++ {
+			copy(buf[:sha256.Size], layer[2*i][:])
+			copy(buf[sha256.Size:], layer[2*i+1][:])
+			layer[i] = sha256.Sum256(buf[:])
+		}
+		layer = layer[:len(layer)/2]
+	}
+
+	return layer[0]
+}
+
+// blockHashes calcule les hashes SHA-256 des blocs de 16 KiB d'une portion de fichier.
+// Le dernier bloc peut être plus court.
+func blockHashes(data []byte) [][sha256.Size]byte {
+	hashes := make([][sha256.Size]byte, 0, (len(data)+blockSize-1)/blockSize)
+	for offset := 0; offset < len(data); offset += blockSize {
+		end := min(offset+blockSize, len(data))
+		hashes = append(hashes, sha256.Sum256(data[offset:end]))
+	}
+	return hashes
+}
+
+// pieceLayerNode calcule le nœud de la couche "piece layers" couvrant une pièce.
+// Les blocs manquants (dernière pièce d'un fichier) sont des hashes nuls.
+func pieceLayerNode(leaves [][sha256.Size]byte, pieceLength int64) [sha256.Size]byte {
+	padded := make([][sha256.Size]byte, pieceLength/blockSize)
+	copy(padded, leaves)
+	return merkleRoot(padded, [sha256.Size]byte{})
+}
+
+// piecePadHash retourne le hash de bourrage de la couche "piece layers":
+// la racine d'un sous-arbre de blocs nuls couvrant une pièce
+func piecePadHash(pieceLength int64) [sha256.Size]byte {
+	return pieceLayerNode(nil, pieceLength)
+}
+
+// nextPowerOfTwo retourne la plus petite puissance de deux supérieure ou égale à n
+func nextPowerOfTwo(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	return 1 << bits.Len(uint(n-1))
+}
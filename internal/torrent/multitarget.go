@@ -0,0 +1,283 @@
+package torrent
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sync"
+)
+
+// readChunkSize est la taille des lectures séquentielles communes à plusieurs cibles
+const readChunkSize = 4 << 20
+
+// hashTarget est un flux de pièces à calculer pendant une lecture commune des données.
+// Chaque cible a sa propre taille de pièce et sa propre disposition (bourrage v1 ou alignement v2).
+type hashTarget struct {
+	layout      []sourceFile         // fichiers dans l'ordre du flux, bourrage compris
+	pieceLength int64                // taille des pièces de la cible
+	cached      func(index int) bool // optionnelle: pièce déjà connue, ni copiée ni hachée
+	hash        func(index int, data []byte)
+}
+
+// targetJob est une pièce complète d'une cible en attente de hachage
+type targetJob struct {
+	stream *targetStream
+	index  int
+	data   []byte
+}
+
+// targetStream découpe en pièces le flux d'une cible à partir des données lues une seule fois
+type targetStream struct {
+	*hashTarget
+	next       int // prochaine entrée de layout
+	index      int // pièce en cours
+	fill       int64
+	buf        []byte
+	skip       bool // pièce en cours connue du cache
+	buffers    chan []byte
+	allocated  int
+	maxBuffers int
+}
+
+// hashTargets lit une seule fois, séquentiellement, les fichiers réels (sans bourrage) et alimente
+// toutes les cibles à la fois: les données ne sont lues qu'une fois quel que soit le nombre de torrents.
+// Les pièces de toutes les cibles sont hachées sur un pool commun de workers.
+func hashTargets(ctx context.Context, files []sourceFile, targets []*hashTarget, workers int, progress ProgressFunc) error {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	total := streamLength(files)
+
+	for _, t := range targets {
+		if t.pieceLength <= 0 {
+			return fmt.Errorf("taille de pièce invalide: %d", t.pieceLength)
+		}
+	}
+
+	// Toutes les pièces sont connues du cache: aucune lecture nécessaire
+	if allTargetsCached(targets) {
+		if progress != nil {
+			progress(total, total)
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// La mémoire est bornée à environ workers + 2 pièces par cible
+	perTarget := max(2, workers/len(targets)+1)
+	streams := make([]*targetStream, len(targets))
+	for i, t := range targets {
+		streams[i] = &targetStream{
+			hashTarget: t,
+			buffers:    make(chan []byte, perTarget),
+			maxBuffers: perTarget,
+		}
+	}
+
+	jobs := make(chan targetJob, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				job.stream.hash(job.index, job.data)
+				job.stream.buffers <- job.data[:cap(job.data)]
+			}
+		}()
+	}
+
+	readErr := func() error {
+		defer close(jobs)
+
+		chunk := make([]byte, min(readChunkSize, max(total, 1)))
+		var read int64
+		for _, f := range files {
+			for _, s := range streams {
+				if err := s.startFile(ctx, f, jobs); err != nil {
+					return err
+				}
+			}
+			if err := readFileChunks(ctx, f, chunk, func(data []byte) error {
+				for _, s := range streams {
+					if err := s.feed(ctx, data, int64(len(data)), jobs); err != nil {
+						return err
+					}
+				}
+				read += int64(len(data))
+				if progress != nil {
+					progress(read, total)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+
+		for _, s := range streams {
+			if err := s.finish(ctx, jobs); err != nil {
+				return err
+			}
+		}
+		return nil
+	}()
+
+	wg.Wait()
+	return readErr
+}
+
+// allTargetsCached indique si toutes les pièces de toutes les cibles sont connues du cache
+func allTargetsCached(targets []*hashTarget) bool {
+	for _, t := range targets {
+		if t.cached == nil {
+			return false
+		}
+		numPieces := int((streamLength(t.layout) + t.pieceLength - 1) / t.pieceLength)
+		for index := 0; index < numPieces; index++ {
+			if !t.cached(index) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// readFileChunks lit un fichier par blocs de la taille de chunk et vérifie sa taille
+func readFileChunks(ctx context.Context, f sourceFile, chunk []byte, fn func(data []byte) error) error {
+	if f.Length == 0 {
+		return nil
+	}
+
+	file, err := os.Open(f.Path)
+	if err != nil {
+		return fmt.Errorf("erreur ouverture fichier: %w", err)
+	}
+	defer file.Close()
+
+	for remaining := f.Length; remaining > 0; {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		n := min(int64(len(chunk)), remaining)
+		if _, err := io.ReadFull(file, chunk[:n]); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return fmt.Errorf("%s: fichier plus court que prévu", f.Path)
+			}
+			return err
+		}
+		if err := fn(chunk[:n]); err != nil {
+			return err
+		}
+		remaining -= n
+	}
+	return nil
+}
+
+// startFile insère le bourrage qui précède le fichier f dans le flux de la cible
+func (s *targetStream) startFile(ctx context.Context, f sourceFile, jobs chan<- targetJob) error {
+	if err := s.skipPadding(ctx, jobs); err != nil {
+		return err
+	}
+	if s.next >= len(s.layout) || s.layout[s.next].Length != f.Length {
+		return fmt.Errorf("disposition incohérente pour %s", f.Path)
+	}
+	s.next++
+	return nil
+}
+
+// skipPadding ajoute au flux les fichiers de bourrage à la position courante de la disposition
+func (s *targetStream) skipPadding(ctx context.Context, jobs chan<- targetJob) error {
+	for s.next < len(s.layout) && s.layout[s.next].Padding {
+		if err := s.feed(ctx, nil, s.layout[s.next].Length, jobs); err != nil {
+			return err
+		}
+		s.next++
+	}
+	return nil
+}
+
+// finish ajoute le bourrage final et envoie la dernière pièce, éventuellement incomplète
+func (s *targetStream) finish(ctx context.Context, jobs chan<- targetJob) error {
+	if err := s.skipPadding(ctx, jobs); err != nil {
+		return err
+	}
+	if s.fill > 0 {
+		return s.submit(ctx, jobs)
+	}
+	return nil
+}
+
+// feed ajoute n octets au flux de la cible: data, ou des zéros (bourrage) si data est nil
+func (s *targetStream) feed(ctx context.Context, data []byte, n int64, jobs chan<- targetJob) error {
+	for n > 0 {
+		if s.fill == 0 && s.buf == nil && !s.skip {
+			if s.cached != nil && s.cached(s.index) {
+				s.skip = true
+			} else {
+				buf, err := s.acquire(ctx)
+				if err != nil {
+					return err
+				}
+				s.buf = buf
+			}
+		}
+
+		k := min(n, s.pieceLength-s.fill)
+		if !s.skip {
+			if data != nil {
+				copy(s.buf[s.fill:], data[:k])
+			} else {
+				clear(s.buf[s.fill : s.fill+k])
+			}
+		}
+		if data != nil {
+			data = data[k:]
+		}
+		s.fill += k
+		n -= k
+
+		if s.fill == s.pieceLength {
+			if err := s.submit(ctx, jobs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// submit confie la pièce en cours aux workers (sauf si elle est connue du cache) et passe à la suivante
+func (s *targetStream) submit(ctx context.Context, jobs chan<- targetJob) error {
+	if !s.skip {
+		select {
+		case jobs <- targetJob{stream: s, index: s.index, data: s.buf[:s.fill]}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	s.buf, s.skip, s.fill = nil, false, 0
+	s.index++
+	return nil
+}
+
+// acquire retourne un buffer de pièce libre, en attendant qu'un worker en rende un si besoin
+func (s *targetStream) acquire(ctx context.Context) ([]byte, error) {
+	if s.allocated < s.maxBuffers {
+		select {
+		case buf := <-s.buffers:
+			return buf, nil
+		default:
+			s.allocated++
+			return make([]byte, s.pieceLength), nil
+		}
+	}
+	select {
+	case buf := <-s.buffers:
+		return buf, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
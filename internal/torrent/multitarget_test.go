@@ -0,0 +1,155 @@
+package torrent
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeMixedRelease écrit un dossier de plusieurs fichiers de tailles non alignées sur les pièces
+func writeMixedRelease(t *testing.T) string {
+	t.Helper()
+
+	dir := filepath.Join(t.TempDir(), "Release")
+	for i, size := range []int{150000, 1500, 0, 70000, 33} {
+		writeRandomFile(t, filepath.Join(dir, string(rune('a'+i))+".bin"), size, int64(i))
+	}
+	return dir
+}
+
+// v1Target retourne une cible de hachage v1 sans bourrage
+func v1Target(files []sourceFile, pieceLength int64, cache *PieceCache) *hashTarget {
+	h := newV1Hasher(files, pieceLength, cache)
+	return &hashTarget{layout: files, pieceLength: pieceLength, cached: h.cachedFunc(), hash: h.hash}
+}
+
+func TestCreateForProfilesSinglePass(t *testing.T) {
+	dir := writeMixedRelease(t)
+	out := t.TempDir()
+
+	// Tailles de pièce et formats différents: chaque profil est une cible de hachage distincte
+	profiles := []Profile{
+		{Name: "v1-16k", Source: "A", MinPieceSize: 16 << 10, MaxPieceSize: 16 << 10},
+		{Name: "v1-32k", Source: "B", MinPieceSize: 32 << 10, MaxPieceSize: 32 << 10},
+		{Name: "v2", Source: "C", MinPieceSize: 16 << 10, MaxPieceSize: 16 << 10, Version: VersionV2},
+		{Name: "hybrid", Source: "D", MinPieceSize: 64 << 10, MaxPieceSize: 64 << 10, Version: VersionHybrid},
+		{Name: "v1-16k-bis", Source: "E", MinPieceSize: 16 << 10, MaxPieceSize: 16 << 10},
+	}
+
+	for _, padding := range []bool{false, true} {
+		newGenerator := func() *Generator {
+			g := NewGenerator()
+			g.SetCreationDate(time.Time{})
+			g.SetPadding(padding, 0)
+			return g
+		}
+
+		outputs := make([]string, len(profiles))
+		for i, p := range profiles {
+			outputs[i] = filepath.Join(out, p.Name+".torrent")
+		}
+		if err := newGenerator().CreateForProfiles(context.Background(), dir, profiles, outputs); err != nil {
+			t.Fatal(err)
+		}
+
+		// Chaque torrent est identique à celui généré seul pour le même profil
+		for i, p := range profiles {
+			g := newGenerator()
+			if p.Version != "" {
+				g.SetVersion(p.Version)
+			}
+			if err := g.ApplyProfile(p); err != nil {
+				t.Fatal(err)
+			}
+			alone := filepath.Join(out, p.Name+".alone.torrent")
+			if err := g.CreateFromDirectory(context.Background(), dir, alone); err != nil {
+				t.Fatal(err)
+			}
+
+			a, _ := os.ReadFile(outputs[i])
+			b, _ := os.ReadFile(alone)
+			if !bytes.Equal(a, b) {
+				t.Errorf("padding=%v, profil %s: le torrent multi-cible diffère du torrent généré seul", padding, p.Name)
+			}
+		}
+	}
+}
+
+func TestHashTargetsProgress(t *testing.T) {
+	dir := writeMixedRelease(t)
+	_, files, err := collectFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targets := []*hashTarget{
+		v1Target(files, 16<<10, nil),
+		v1Target(files, 32<<10, nil),
+	}
+
+	// Les données ne sont lues qu'une fois, quel que soit le nombre de cibles
+	total := streamLength(files)
+	var last int64
+	err = hashTargets(context.Background(), files, targets, 2, func(hashed, max int64) {
+		if max != total {
+			t.Errorf("total = %d, want %d", max, total)
+		}
+		last = hashed
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last != total {
+		t.Errorf("octets lus = %d, want %d", last, total)
+	}
+}
+
+func TestHashTargetsCancel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data")
+	writeRandomFile(t, path, 1<<20, 1)
+	files := []sourceFile{{Path: path, Length: 1 << 20}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	targets := []*hashTarget{
+		v1Target(files, 16<<10, nil),
+		v1Target(files, 64<<10, nil),
+	}
+	if err := hashTargets(ctx, files, targets, 2, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("hashTargets() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestHashTargetsCache(t *testing.T) {
+	dir := writeMixedRelease(t)
+	_, files, err := collectFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache, err := OpenPieceCache(filepath.Join(t.TempDir(), "cache"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want16, _ := readV1Pieces(context.Background(), files, 16<<10, 2, nil, cache)
+	want32, _ := readV1Pieces(context.Background(), files, 32<<10, 2, nil, nil)
+
+	// La cible 16 KiB est entièrement en cache: seules les pièces 32 KiB sont calculées
+	h16 := newV1Hasher(files, 16<<10, cache)
+	h32 := newV1Hasher(files, 32<<10, cache)
+	targets := []*hashTarget{
+		{layout: files, pieceLength: 16 << 10, cached: h16.cachedFunc(), hash: h16.hash},
+		{layout: files, pieceLength: 32 << 10, cached: h32.cachedFunc(), hash: h32.hash},
+	}
+	if err := hashTargets(context.Background(), files, targets, 2, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(h16.pieces, want16) || !bytes.Equal(h32.pieces, want32) {
+		t.Error("les pièces multi-cibles diffèrent du hachage séparé")
+	}
+}
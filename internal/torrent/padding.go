@@ -0,0 +1,88 @@
+package torrent
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Attributs de fichiers (BEP 47)
+const (
+	attrPadding    = "p"
+	attrHidden     = "h"
+	attrExecutable = "x"
+)
+
+// legacyPaddingPrefix est le nom des fichiers de bourrage antérieurs à BEP 47 (BitComet)
+const legacyPaddingPrefix = "_____padding_file_"
+
+// paddingFile crée un fichier de bourrage de length octets (lu comme des zéros)
+func paddingFile(length int64) sourceFile {
+	return sourceFile{
+		Length:  length,
+		Parts:   []string{".pad", strconv.FormatInt(length, 10)},
+		Padding: true,
+	}
+}
+
+// padFiles insère des fichiers de bourrage (BEP 47) dans un torrent v1 pour que chaque fichier
+// d'au moins minSize octets (0 = taille de pièce) occupe ses propres pièces: il commence et se
+// termine sur une frontière de pièce. Ses pièces ne dépendent alors que de son contenu, ce qui
+// permet le cross-seed du fichier seul et la vérification des téléchargements partiels.
+// Les petits fichiers (NFO, sous-titres) restent regroupés pour ne pas multiplier le bourrage.
+func padFiles(files []sourceFile, pieceLength, minSize int64) []sourceFile {
+	if minSize <= 0 {
+		minSize = pieceLength
+	}
+
+	padded := make([]sourceFile, 0, len(files)+2)
+	var offset int64
+	align := func() {
+		if rest := offset % pieceLength; rest != 0 {
+			padded = append(padded, paddingFile(pieceLength-rest))
+			offset += pieceLength - rest
+		}
+	}
+
+	for i, f := range files {
+		large := f.Length >= minSize
+		if large {
+			align()
+		}
+		padded = append(padded, f)
+		offset += f.Length
+		// Pas de bourrage en fin de torrent: il n'aurait aucune utilité
+		if large && i < len(files)-1 {
+			align()
+		}
+	}
+	return padded
+}
+
+// fileAttr retourne les attributs BEP 47 d'un fichier source (h: caché, x: exécutable)
+func fileAttr(name string, mode os.FileMode) string {
+	var attr string
+	if strings.HasPrefix(name, ".") {
+		attr += attrHidden
+	}
+	if mode&0111 != 0 {
+		attr += attrExecutable
+	}
+	return attr
+}
+
+// clearFileAttrs retire les attributs BEP 47 des fichiers source
+func clearFileAttrs(files []sourceFile) {
+	for i := range files {
+		files[i].Attr = ""
+	}
+}
+
+// isPadding indique si une entrée de la liste files est un fichier de bourrage, par son attribut
+// (BEP 47) ou par le nom utilisé avant BEP 47
+func (f fileEntry) isPadding() bool {
+	if strings.Contains(f.Attr, attrPadding) {
+		return true
+	}
+	return len(f.Path) > 0 && strings.HasPrefix(f.Path[len(f.Path)-1], legacyPaddingPrefix)
+}
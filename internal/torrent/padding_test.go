@@ -0,0 +1,247 @@
+package torrent
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPadFiles(t *testing.T) {
+	const pl = 16 << 10
+	files := []sourceFile{
+		{Parts: []string{"film.mkv"}, Length: 5*pl + 100},
+		{Parts: []string{"film.nfo"}, Length: 3000},
+		{Parts: []string{"film.srt"}, Length: 5000},
+		{Parts: []string{"sample.mkv"}, Length: 2*pl + 7},
+		{Parts: []string{"z.txt"}, Length: 10},
+	}
+
+	padded := padFiles(files, pl, 0)
+
+	// Chaque gros fichier commence sur une frontière de pièce et le fichier suivant aussi
+	var offset int64
+	var layout []string
+	alignNext := false
+	for _, f := range padded {
+		name := strings.Join(f.Parts, "/")
+		if !f.Padding {
+			large := f.Length >= pl
+			if (large || alignNext) && offset%pl != 0 {
+				t.Errorf("%s commence à l'octet %d, hors frontière de pièce", name, offset)
+			}
+			alignNext = large
+		}
+		offset += f.Length
+		layout = append(layout, name)
+	}
+
+	// Les petits fichiers restent groupés: un seul bourrage entre eux et le fichier suivant
+	want := []string{"film.mkv", ".pad/16284", "film.nfo", "film.srt", ".pad/8384", "sample.mkv", ".pad/16377", "z.txt"}
+	if strings.Join(layout, " ") != strings.Join(want, " ") {
+		t.Errorf("disposition = %v, attendu %v", layout, want)
+	}
+
+	// Seuil explicite: sample.mkv (32 KiB) n'est plus aligné
+	padded = padFiles(files, pl, 4*pl)
+	if got := len(padded) - len(files); got != 1 {
+		t.Errorf("%d fichier(s) de bourrage avec un seuil de 64 KiB, attendu 1", got)
+	}
+}
+
+// writePaddedRelease écrit une release multi-fichiers: vidéo, NFO, script exécutable et fichier caché
+func writePaddedRelease(t *testing.T) string {
+	t.Helper()
+
+	dir := filepath.Join(t.TempDir(), "Release")
+	files := map[string][]byte{
+		"film.mkv":      bytes.Repeat([]byte("video"), 30000),
+		"film.nfo":      []byte("NFO"),
+		"sample.mkv":    bytes.Repeat([]byte("sample"), 9000),
+		"run.sh":        []byte("#!/bin/sh\n"),
+		".hidden.txt":   []byte("caché"),
+		"Subs/film.srt": bytes.Repeat([]byte("1\n00:00:01,000 --> 00:00:02,000\n"), 100),
+	}
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		mode := os.FileMode(0644)
+		if strings.HasSuffix(name, ".sh") {
+			mode = 0755
+		}
+		if err := os.WriteFile(path, data, mode); err != nil {
+			t.Fatal(err)
+		}
+		os.Chmod(path, mode)
+	}
+	return dir
+}
+
+func TestCreatePadded(t *testing.T) {
+	dir := writePaddedRelease(t)
+	output := filepath.Join(t.TempDir(), "release.torrent")
+
+	g := NewGenerator()
+	g.SetPieceSize(16 << 10)
+	g.SetPadding(true, 0)
+	if err := g.CreateFromDirectory(context.Background(), dir, output); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := Inspect(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, anomaly := range report.Anomalies {
+		if strings.Contains(anomaly, "bourrage") {
+			t.Errorf("anomalie inattendue: %s", anomaly)
+		}
+	}
+	if report.TotalSize != 150000+3+54000+10+6+3200 {
+		t.Errorf("taille totale = %d, le bourrage ne doit pas être compté", report.TotalSize)
+	}
+
+	attrs := make(map[string]string)
+	pads := 0
+	for _, f := range report.Files {
+		if f.Padding {
+			pads++
+			continue
+		}
+		attrs[f.Path] = f.Attr
+	}
+	if pads != 3 {
+		t.Errorf("%d fichiers de bourrage, attendu 3 (autour de film.mkv et avant sample.mkv)", pads)
+	}
+	for path, want := range map[string]string{"run.sh": "x", ".hidden.txt": "h", "film.mkv": "", "Subs/film.srt": ""} {
+		if attrs[path] != want {
+			t.Errorf("attr %s = %q, attendu %q", path, attrs[path], want)
+		}
+	}
+
+	result, err := Verify(context.Background(), output, dir, 2, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.OK() {
+		t.Fatalf("vérification en échec: %+v", result.Files)
+	}
+
+	// Une corruption de la vidéo n'invalide que ses propres pièces
+	f, err := os.OpenFile(filepath.Join(dir, "film.mkv"), os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteAt([]byte("XXXX"), 150000-2)
+	f.Close()
+
+	result, err = Verify(context.Background(), output, dir, 2, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fr := range result.Files {
+		want := FileOK
+		if fr.Path == "film.mkv" {
+			want = FileCorrupt
+		}
+		if fr.State != want {
+			t.Errorf("%s: état %s, attendu %s", fr.Path, fr.State, want)
+		}
+	}
+}
+
+func TestReproducibleDropsExecutable(t *testing.T) {
+	dir := writePaddedRelease(t)
+	output := filepath.Join(t.TempDir(), "release.torrent")
+
+	g := NewGenerator()
+	g.SetReproducible(true)
+	g.SetFileAttributes(true)
+	if err := g.CreateFromDirectory(context.Background(), dir, output); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := Inspect(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range report.Files {
+		if strings.Contains(f.Attr, "x") {
+			t.Errorf("%s: attribut x présent en mode reproductible", f.Path)
+		}
+	}
+}
+
+func TestFileAttributesOptIn(t *testing.T) {
+	dir := writePaddedRelease(t)
+
+	// Sans bourrage ni option explicite, aucun attribut: l'infohash reste celui d'avant BEP 47
+	attrs := func(g *Generator) map[string]string {
+		t.Helper()
+		output := filepath.Join(t.TempDir(), "release.torrent")
+		if err := g.CreateFromDirectory(context.Background(), dir, output); err != nil {
+			t.Fatal(err)
+		}
+		report, err := Inspect(output)
+		if err != nil {
+			t.Fatal(err)
+		}
+		found := make(map[string]string)
+		for _, f := range report.Files {
+			if f.Attr != "" {
+				found[f.Path] = f.Attr
+			}
+		}
+		return found
+	}
+
+	for _, version := range []Version{VersionV1, VersionV2} {
+		g := NewGenerator()
+		g.SetVersion(version)
+		if found := attrs(g); len(found) != 0 {
+			t.Errorf("%s: attributs écrits par défaut: %v", version, found)
+		}
+	}
+
+	g := NewGenerator()
+	g.SetFileAttributes(true)
+	if found := attrs(g); found["run.sh"] != "x" || found[".hidden.txt"] != "h" {
+		t.Errorf("attributs = %v, attendu run.sh: x et .hidden.txt: h", found)
+	}
+}
+
+func TestPaddingAnomalies(t *testing.T) {
+	const pl = 16 << 10
+	files := []sourceFile{
+		{Parts: []string{"a.mkv"}, Length: pl + 10},
+		paddingFile(100),
+		{Parts: []string{"b.mkv"}, Length: pl - 110},
+		paddingFile(pl),
+	}
+
+	anomalies := paddingAnomalies(files, pl)
+	if len(anomalies) != 2 || !strings.Contains(anomalies[0], "mal aligné") || !strings.Contains(anomalies[1], "fin de torrent") {
+		t.Errorf("anomalies = %q", anomalies)
+	}
+}
+
+func TestLegacyPadding(t *testing.T) {
+	tests := []struct {
+		entry fileEntry
+		want  bool
+	}{
+		{fileEntry{Attr: "p", Path: []string{".pad", "100"}}, true},
+		{fileEntry{Path: []string{"_____padding_file_0_if you see this file, please update to BitComet 0.85 or above____"}}, true},
+		{fileEntry{Attr: "x", Path: []string{"run.sh"}}, false},
+		{fileEntry{Path: []string{"film.mkv"}}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.entry.isPadding(); got != tt.want {
+			t.Errorf("isPadding(%v) = %t, attendu %t", tt.entry.Path, got, tt.want)
+		}
+	}
+}
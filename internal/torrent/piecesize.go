@@ -0,0 +1,114 @@
+package torrent
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PieceStrategy détermine comment la taille des pièces est choisie
+type PieceStrategy string
+
+const (
+	// PieceAuto choisit la taille de pièce selon la taille totale (table par paliers)
+	PieceAuto PieceStrategy = "auto"
+	// PieceFixed utilise la taille de pièce définie avec SetPieceSize
+	PieceFixed PieceStrategy = "fixed"
+	// PieceCount vise un nombre de pièces défini avec SetTargetPieces
+	PieceCount PieceStrategy = "count"
+)
+
+const (
+	// minPieceLength est la plus petite taille de pièce acceptée (un bloc BEP 52)
+	minPieceLength = blockSize
+	// maxPieceLength est la plus grande taille de pièce proposée automatiquement
+	maxPieceLength = 64 * 1024 * 1024
+	// defaultMaxPieceLength plafonne les stratégies auto et count sans taille maximale explicite:
+	// au-delà, certains clients et trackers refusent le torrent
+	defaultMaxPieceLength = 16 * 1024 * 1024
+	// defaultTargetPieces est le nombre de pièces visé par défaut par la stratégie count
+	defaultTargetPieces = 2000
+)
+
+// ParsePieceStrategy convertit une valeur de configuration en stratégie de taille de pièce
+func ParsePieceStrategy(s string) (PieceStrategy, error) {
+	switch strategy := PieceStrategy(strings.ToLower(strings.TrimSpace(s))); strategy {
+	case PieceAuto, PieceFixed, PieceCount:
+		return strategy, nil
+	case "":
+		return PieceAuto, nil
+	default:
+		return "", fmt.Errorf("stratégie de taille de pièce inconnue: %s (auto, fixed ou count)", s)
+	}
+}
+
+// choosePieceLength applique la stratégie du générateur puis les limites min/max
+func (g *Generator) choosePieceLength(totalSize int64) (int64, error) {
+	// Les limites sont ramenées aux puissances de deux qu'elles autorisent
+	lower := max(g.minPieceSize, minPieceLength)
+	lower = int64(nextPowerOfTwo(int(lower)))
+	upper := g.maxPieceSize
+	if upper > 0 {
+		upper = int64(nextPowerOfTwo(int(upper)))
+		if upper > g.maxPieceSize {
+			upper /= 2
+		}
+		if upper < lower {
+			return 0, fmt.Errorf("limites de taille de pièce incompatibles (min %s, max %s)", formatSize(g.minPieceSize), formatSize(g.maxPieceSize))
+		}
+	}
+
+	// Les pièces de 32 et 64 MiB ne sont proposées que si la taille maximale les autorise
+	ceiling := int64(defaultMaxPieceLength)
+	if g.maxPieceSize > defaultMaxPieceLength {
+		ceiling = maxPieceLength
+	}
+
+	var size int64
+	switch g.pieceStrategy {
+	case PieceFixed:
+		size = g.pieceSize
+		if size < minPieceLength || size&(size-1) != 0 {
+			return 0, fmt.Errorf("taille de pièce invalide: %d (puissance de deux >= 16 KiB requise)", size)
+		}
+		// Une taille imposée n'est jamais corrigée en silence
+		if size < lower || (upper > 0 && size > upper) {
+			return 0, fmt.Errorf("taille de pièce %s hors des limites autorisées (%s - %s)", formatSize(size), formatSize(lower), formatUpper(upper))
+		}
+		return size, nil
+	case PieceCount:
+		target := int64(g.targetPieces)
+		if target <= 0 {
+			target = defaultTargetPieces
+		}
+		// Plus petite puissance de deux donnant au plus target pièces
+		size = minPieceLength
+		for size < ceiling && (totalSize+size-1)/size > target {
+			size *= 2
+		}
+	default:
+		size = min(g.calculatePieceLength(totalSize), ceiling)
+	}
+
+	size = max(size, lower)
+	if upper > 0 {
+		size = min(size, upper)
+	}
+	return size, nil
+}
+
+// restrictPieceSize resserre les limites de taille de pièce du générateur (0 = pas de limite)
+func (g *Generator) restrictPieceSize(minSize, maxSize int64) {
+	if minSize > g.minPieceSize {
+		g.minPieceSize = minSize
+	}
+	if maxSize > 0 && (g.maxPieceSize == 0 || maxSize < g.maxPieceSize) {
+		g.maxPieceSize = maxSize
+	}
+}
+
+func formatUpper(upper int64) string {
+	if upper == 0 {
+		return "∞"
+	}
+	return formatSize(upper)
+}
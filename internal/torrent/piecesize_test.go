@@ -0,0 +1,95 @@
+package torrent
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestChoosePieceLength(t *testing.T) {
+	const (
+		KiB = int64(1024)
+		MiB = 1024 * KiB
+		GiB = 1024 * MiB
+	)
+
+	tests := []struct {
+		name    string
+		setup   func(g *Generator)
+		total   int64
+		want    int64
+		wantErr bool
+	}{
+		{name: "Auto petit fichier", total: 700 * MiB, want: 1 * MiB},
+		{name: "Auto 8 Go", total: 8 * GiB, want: 8 * MiB},
+		{name: "Auto au-delà de 16 Go", total: 40 * GiB, want: 16 * MiB},
+		{name: "Auto très gros", total: 200 * GiB, want: 16 * MiB},
+		{name: "Auto au-delà de 16 Go avec max", total: 40 * GiB, setup: func(g *Generator) { g.SetPieceSizeBounds(0, 64*MiB) }, want: 32 * MiB},
+		{name: "Auto très gros avec max", total: 200 * GiB, setup: func(g *Generator) { g.SetPieceSizeBounds(0, 64*MiB) }, want: 64 * MiB},
+		{name: "Auto très gros avec max à 32 Mo", total: 200 * GiB, setup: func(g *Generator) { g.SetPieceSizeBounds(0, 32*MiB) }, want: 32 * MiB},
+		{name: "Auto borné par le max", total: 8 * GiB, setup: func(g *Generator) { g.SetPieceSizeBounds(0, 4*MiB) }, want: 4 * MiB},
+		{name: "Auto borné par le min", total: 700 * MiB, setup: func(g *Generator) { g.SetPieceSizeBounds(2*MiB, 0) }, want: 2 * MiB},
+		{name: "Max non puissance de deux", total: 8 * GiB, setup: func(g *Generator) { g.SetPieceSizeBounds(0, 3*MiB) }, want: 2 * MiB},
+		{name: "Fixe", total: 8 * GiB, setup: func(g *Generator) { g.SetPieceSize(512 * KiB) }, want: 512 * KiB},
+		{name: "Fixe non puissance de deux", total: 8 * GiB, setup: func(g *Generator) { g.SetPieceSize(3 * MiB) }, wantErr: true},
+		{name: "Fixe hors limites", total: 8 * GiB, setup: func(g *Generator) {
+			g.SetPieceSize(32 * MiB)
+			g.SetPieceSizeBounds(0, 16*MiB)
+		}, wantErr: true},
+		{name: "Nombre de pièces", total: 10 * GiB, setup: func(g *Generator) {
+			g.SetPieceStrategy(PieceCount)
+			g.SetTargetPieces(2000)
+		}, want: 8 * MiB},
+		{name: "Nombre de pièces par défaut", total: 3 * GiB, setup: func(g *Generator) { g.SetPieceStrategy(PieceCount) }, want: 2 * MiB},
+		{name: "Nombre de pièces plafonné", total: 200 * GiB, setup: func(g *Generator) { g.SetPieceStrategy(PieceCount) }, want: 16 * MiB},
+		{name: "Nombre de pièces minuscule", total: 100, setup: func(g *Generator) { g.SetPieceStrategy(PieceCount) }, want: 16 * KiB},
+		{name: "Limites incompatibles", total: GiB, setup: func(g *Generator) { g.SetPieceSizeBounds(8*MiB, 4*MiB) }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGenerator()
+			if tt.setup != nil {
+				tt.setup(g)
+			}
+			got, err := g.choosePieceLength(tt.total)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("choosePieceLength() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("choosePieceLength() = %s, want %s", formatSize(got), formatSize(tt.want))
+			}
+		})
+	}
+}
+
+func TestCreateForProfilesPieceBounds(t *testing.T) {
+	dir := t.TempDir()
+	data := filepath.Join(dir, "movie.mkv")
+	writeRandomFile(t, data, 100000, 1)
+
+	profiles := []Profile{
+		{Name: "a", Private: true, MaxPieceSize: 256 * 1024},
+		{Name: "b", Private: true, MaxPieceSize: 64 * 1024},
+	}
+	outputs := []string{filepath.Join(dir, "a.torrent"), filepath.Join(dir, "b.torrent")}
+	if err := NewGenerator().CreateForProfiles(context.Background(), data, profiles, outputs); err != nil {
+		t.Fatal(err)
+	}
+
+	// Chaque profil reçoit sa propre taille de pièce, dans ses limites
+	for i, want := range []int64{256 * 1024, 64 * 1024} {
+		r, err := Inspect(outputs[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.PieceLength != want {
+			t.Errorf("%s: piece length = %d, want %d", outputs[i], r.PieceLength, want)
+		}
+	}
+
+	profiles[1].MinPieceSize = 128 * 1024
+	if err := NewGenerator().CreateForProfiles(context.Background(), data, profiles, outputs); err == nil {
+		t.Error("des limites incompatibles dans un profil devraient être refusées")
+	}
+}
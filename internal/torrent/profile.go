@@ -0,0 +1,66 @@
+package torrent
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Profile regroupe les paramètres propres à un tracker
+type Profile struct {
+	Name         string
+	AnnounceList [][]string
+	Source       string
+	Comment      string
+	Private      bool
+	WebSeeds     []string // ajoutées aux web seeds du générateur
+	MinPieceSize int64    // 0 = pas de limite
+	MaxPieceSize int64    // 0 = pas de limite
+	Version      Version  // format du torrent (vide = format du générateur)
+}
+
+// CheckPieceLength vérifie qu'une taille de pièce respecte les limites du tracker
+func (p Profile) CheckPieceLength(pieceLength int64) error {
+	if p.MinPieceSize > 0 && pieceLength < p.MinPieceSize {
+		return fmt.Errorf("taille de pièce %s inférieure au minimum du profil %s (%s)", formatSize(pieceLength), p.Name, formatSize(p.MinPieceSize))
+	}
+	if p.MaxPieceSize > 0 && pieceLength > p.MaxPieceSize {
+		return fmt.Errorf("taille de pièce %s supérieure au maximum du profil %s (%s)", formatSize(pieceLength), p.Name, formatSize(p.MaxPieceSize))
+	}
+	return nil
+}
+
+// CheckVersion vérifie que le format d'un torrent existant est celui imposé par le profil
+func (p Profile) CheckVersion(v Version) error {
+	if p.Version != "" && p.Version != v {
+		return fmt.Errorf("format %s différent de celui du profil %s (%s)", v, p.Name, p.Version)
+	}
+	return nil
+}
+
+// ApplyProfile applique les paramètres d'un profil de tracker au générateur
+func (g *Generator) ApplyProfile(p Profile) error {
+	if err := g.SetAnnounceList(p.AnnounceList); err != nil {
+		return err
+	}
+
+	if len(p.WebSeeds) > 0 {
+		webSeeds := append([]string(nil), g.webSeeds...)
+		for _, u := range p.WebSeeds {
+			if !slices.Contains(webSeeds, u) {
+				webSeeds = append(webSeeds, u)
+			}
+		}
+		if err := g.SetWebSeeds(webSeeds); err != nil {
+			return err
+		}
+	}
+
+	g.restrictPieceSize(p.MinPieceSize, p.MaxPieceSize)
+	g.SetSource(p.Source)
+	g.SetPrivate(p.Private)
+	if p.Comment != "" {
+		g.SetComment(p.Comment)
+	}
+
+	return nil
+}
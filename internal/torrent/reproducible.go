@@ -0,0 +1,72 @@
+package torrent
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// En mode reproductible, le fichier torrent ne dépend que des données et des paramètres:
+//   - la date de création est omise, ou fixée avec SetCreationDate
+//   - les fichiers sont triés dans l'ordre canonique (déjà le cas hors mode reproductible)
+//   - les noms sont normalisés en Unicode NFC: un même nom ne donne pas le même chemin
+//     selon le système de fichiers (macOS enregistre les accents décomposés, en NFD)
+//   - l'attribut x (exécutable, BEP 47) est omis: les systèmes de fichiers sans droits Unix
+//     (NTFS, exFAT) marquent tous les fichiers comme exécutables
+//
+// Les autres champs (commentaire, created by, trackers, web seeds) sont fixés par la configuration.
+
+// creationTimestamp retourne la date de création à écrire (0 = champ omis)
+func (g *Generator) creationTimestamp() int64 {
+	switch {
+	case g.creationDate != nil:
+		if g.creationDate.IsZero() {
+			return 0
+		}
+		return g.creationDate.Unix()
+	case g.reproducible:
+		return 0
+	default:
+		return time.Now().Unix()
+	}
+}
+
+// canonicalFiles normalise en NFC le nom du torrent et les chemins des fichiers, retire
+// l'attribut exécutable, puis rétablit l'ordre canonique (la normalisation peut changer l'ordre de tri)
+func canonicalFiles(name string, files []sourceFile) string {
+	for i := range files {
+		files[i].Attr = strings.ReplaceAll(files[i].Attr, attrExecutable, "")
+		if files[i].Parts == nil {
+			continue
+		}
+		parts := make([]string, len(files[i].Parts))
+		for j, part := range files[i].Parts {
+			parts[j] = norm.NFC.String(part)
+		}
+		files[i].Parts = parts
+	}
+	sortFiles(files)
+	return norm.NFC.String(name)
+}
+
+// ParseCreationDate convertit une date de création de la configuration: date RFC 3339,
+// date seule (2006-01-02, minuit UTC) ou timestamp Unix. "none" omet le champ (date zéro).
+func ParseCreationDate(s string) (time.Time, error) {
+	raw := strings.TrimSpace(s)
+	if strings.EqualFold(raw, "none") {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.DateOnly, raw); err == nil {
+		return t, nil
+	}
+	if ts, err := strconv.ParseInt(raw, 10, 64); err == nil && ts > 0 {
+		return time.Unix(ts, 0).UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("date de création invalide: %q (RFC 3339, AAAA-MM-JJ, timestamp Unix ou none)", s)
+}
@@ -0,0 +1,175 @@
+package torrent
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/anacrolix/torrent/bencode"
+	"golang.org/x/text/unicode/norm"
+)
+
+// writeRelease écrit les fichiers d'une release dans l'ordre donné, avec des dates de modification
+// distinctes. Le contenu d'un fichier ne dépend que de son nom (normalisé).
+func writeRelease(t *testing.T, dir string, names []string, modTime time.Time) {
+	t.Helper()
+
+	for i, name := range names {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		base := norm.NFC.String(filepath.Base(name))
+		data := bytes.Repeat([]byte(base), 3000+len(base))
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		date := modTime.Add(time.Duration(i) * time.Hour)
+		if err := os.Chtimes(path, date, date); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestReproducible(t *testing.T) {
+	base := t.TempDir()
+
+	// Mêmes données créées dans un ordre différent, à des dates différentes, avec des noms
+	// accentués composés (NFC) d'un côté et décomposés (NFD, comme sous macOS) de l'autre
+	dirA := filepath.Join(base, "a", "Release.Caf\u00e9")
+	dirB := filepath.Join(base, "b", "Release.Cafe\u0301")
+	writeRelease(t, dirA, []string{"film.mkv", "Extras/Making.Of.Caf\u00e9.mkv", "Extras/Affiche.jpg", "Sample/sample.mkv"}, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	writeRelease(t, dirB, []string{"Sample/sample.mkv", "Extras/Affiche.jpg", "Extras/Making.Of.Cafe\u0301.mkv", "film.mkv"}, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	versions := []Version{VersionV1, VersionV2, VersionHybrid}
+	create := func(t *testing.T, version Version, dir, output string) []byte {
+		t.Helper()
+		g := NewGenerator()
+		g.SetVersion(version)
+		g.SetReproducible(true)
+		g.SetCreatedBy("MONGROUPE")
+		g.SetPieceSize(16 << 10)
+		if err := g.SetAnnounceList([][]string{{"https://tracker.example.com/announce"}}); err != nil {
+			t.Fatal(err)
+		}
+		if err := g.CreateFromDirectory(context.Background(), dir, output); err != nil {
+			t.Fatal(err)
+		}
+		data, err := os.ReadFile(output)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	first := make(map[Version][]byte)
+	for _, version := range versions {
+		first[version] = create(t, version, dirA, filepath.Join(base, string(version)+"-1.torrent"))
+	}
+	// Plus d'une seconde d'écart: une date de création aurait changé
+	time.Sleep(1100 * time.Millisecond)
+
+	for _, version := range versions {
+		t.Run(string(version), func(t *testing.T) {
+			second := create(t, version, dirA, filepath.Join(base, string(version)+"-2.torrent"))
+			other := create(t, version, dirB, filepath.Join(base, string(version)+"-3.torrent"))
+
+			if !bytes.Equal(first[version], second) {
+				t.Error("deux générations successives donnent des fichiers différents")
+			}
+			if !bytes.Equal(first[version], other) {
+				t.Error("les mêmes données dans un autre dossier donnent un fichier différent")
+			}
+
+			var raw map[string]bencode.Bytes
+			if err := bencode.Unmarshal(first[version], &raw); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := raw["creation date"]; ok {
+				t.Error("date de création présente en mode reproductible")
+			}
+			if got := string(raw["created by"]); got != "9:MONGROUPE" {
+				t.Errorf("created by = %s, attendu 9:MONGROUPE", got)
+			}
+		})
+	}
+}
+
+func TestCreationDate(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "film.mkv")
+	if err := os.WriteFile(source, bytes.Repeat([]byte("x"), 40000), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fixed := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		reproducible bool
+		date         *time.Time
+		want         int64 // -1 = date de génération
+	}{
+		{name: "défaut", want: -1},
+		{name: "reproductible", reproducible: true, want: 0},
+		{name: "date fixe", date: &fixed, want: fixed.Unix()},
+		{name: "reproductible date fixe", reproducible: true, date: &fixed, want: fixed.Unix()},
+		{name: "date omise", date: &time.Time{}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGenerator()
+			g.SetReproducible(tt.reproducible)
+			if tt.date != nil {
+				g.SetCreationDate(*tt.date)
+			}
+			output := filepath.Join(dir, "out.torrent")
+			before := time.Now().Unix()
+			if err := g.Create(context.Background(), source, output); err != nil {
+				t.Fatal(err)
+			}
+
+			tf, err := loadTorrentFile(output)
+			if err != nil {
+				t.Fatal(err)
+			}
+			switch {
+			case tt.want < 0:
+				if tf.CreationDate < before || tf.CreationDate > time.Now().Unix() {
+					t.Errorf("creation date = %d, date de génération attendue", tf.CreationDate)
+				}
+			case tf.CreationDate != tt.want:
+				t.Errorf("creation date = %d, attendu %d", tf.CreationDate, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCreationDate(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{in: "2024-03-01T12:00:00Z", want: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)},
+		{in: "2024-03-01", want: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{in: "1709294400", want: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)},
+		{in: "none", want: time.Time{}},
+		{in: "hier", wantErr: true},
+		{in: "-5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseCreationDate(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseCreationDate(%q) erreur = %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseCreationDate(%q) = %v, attendu %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package torrent
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/anacrolix/torrent/bencode"
+)
+
+// Retrack réécrit un torrent existant pour un autre tracker sans recalculer les pièces.
+// Les trackers, la source, le flag private, le commentaire et les web seeds sont ceux du
+// générateur et du profil; le reste du dictionnaire info (pièces, fichiers, piece layers) est conservé tel quel.
+func (g *Generator) Retrack(torrentPath, outputPath string, profile Profile) error {
+	return g.RetrackForProfiles(torrentPath, []Profile{profile}, []string{outputPath})
+}
+
+// RetrackForProfiles réécrit un torrent existant pour plusieurs trackers, un fichier de sortie par profil.
+// Tous les profils sont vérifiés avant l'écriture du premier torrent: un profil refusé n'en laisse aucun à moitié.
+func (g *Generator) RetrackForProfiles(torrentPath string, profiles []Profile, outputPaths []string) error {
+	if len(profiles) != len(outputPaths) {
+		return fmt.Errorf("nombre de profils (%d) et de fichiers de sortie (%d) différents", len(profiles), len(outputPaths))
+	}
+
+	tf, err := loadTorrentFile(torrentPath)
+	if err != nil {
+		return err
+	}
+
+	info, err := tf.unmarshalInfo()
+	if err != nil {
+		return err
+	}
+
+	generators := make([]Generator, len(profiles))
+	for i, profile := range profiles {
+		// Les pièces ne peuvent pas être redécoupées ni converties vers un autre format sans tout rehacher
+		if err := profile.CheckPieceLength(info.PieceLength); err != nil {
+			return err
+		}
+		if err := profile.CheckVersion(info.version()); err != nil {
+			return err
+		}
+
+		// Commentaire et web seeds viennent du générateur et du profil cible: ceux du torrent
+		// d'origine sont propres à l'ancien tracker
+		generators[i] = *g
+		if err := generators[i].ApplyProfile(profile); err != nil {
+			return fmt.Errorf("profil %s: %w", profile.Name, err)
+		}
+	}
+
+	// Travailler sur le dictionnaire brut pour ne perdre aucune clé inconnue
+	var raw map[string]bencode.Bytes
+	if err := bencode.Unmarshal(tf.InfoBytes, &raw); err != nil {
+		return fmt.Errorf("erreur décodage info: %w", err)
+	}
+
+	for i, pg := range generators {
+		delete(raw, "private")
+		delete(raw, "source")
+		if pg.private {
+			raw["private"] = bencode.Bytes("i1e")
+		}
+		if pg.source != "" {
+			source, err := bencode.Marshal(pg.source)
+			if err != nil {
+				return fmt.Errorf("erreur encodage source: %w", err)
+			}
+			raw["source"] = source
+		}
+
+		infoBytes, err := bencode.Marshal(raw)
+		if err != nil {
+			return fmt.Errorf("erreur encodage info: %w", err)
+		}
+
+		if err := pg.writeFile(infoBytes, tf.PieceLayers, outputPaths[i]); err != nil {
+			return fmt.Errorf("profil %s: %w", profiles[i].Name, err)
+		}
+	}
+
+	return nil
+}
+
+// GetWebSeedData retourne les variables de web seed d'un torrent existant:
+// nom de release (fichier sans extension ou nom du dossier) et nom du contenu
+func GetWebSeedData(torrentPath string) (WebSeedData, error) {
+	tf, err := loadTorrentFile(torrentPath)
+	if err != nil {
+		return WebSeedData{}, err
+	}
+
+	info, err := tf.unmarshalInfo()
+	if err != nil {
+		return WebSeedData{}, err
+	}
+
+	name := info.Name
+	if info.isSingleFile() {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+
+	return WebSeedData{Name: name, File: info.Name}, nil
+}
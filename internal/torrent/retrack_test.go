@@ -0,0 +1,260 @@
+package torrent
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/anacrolix/torrent/bencode"
+)
+
+func TestRetrack(t *testing.T) {
+	dir := t.TempDir()
+	data := filepath.Join(dir, "Release")
+	writeRandomFile(t, filepath.Join(data, "a.mkv"), 1<<20+12345, 1)
+	writeRandomFile(t, filepath.Join(data, "b.nfo"), 2048, 2)
+
+	for _, version := range []Version{VersionV1, VersionV2, VersionHybrid} {
+		t.Run(string(version), func(t *testing.T) {
+			g := NewGenerator()
+			g.SetVersion(version)
+			if err := g.ApplyProfile(Profile{
+				Name:         "a",
+				AnnounceList: [][]string{{"https://a.example/announce"}},
+				Source:       "A",
+				Comment:      "tracker A",
+				WebSeeds:     []string{"https://a.example/seed/"},
+				Private:      true,
+			}); err != nil {
+				t.Fatal(err)
+			}
+			original := filepath.Join(dir, string(version)+".a.torrent")
+			if err := g.Create(context.Background(), data, original); err != nil {
+				t.Fatal(err)
+			}
+
+			profile := Profile{
+				Name:         "b",
+				AnnounceList: [][]string{{"udp://b.example:1337/announce"}},
+				Source:       "B",
+				Private:      false,
+			}
+			retracked := filepath.Join(dir, string(version)+".b.torrent")
+			if err := NewGenerator().Retrack(original, retracked, profile); err != nil {
+				t.Fatal(err)
+			}
+
+			before, err := loadTorrentFile(original)
+			if err != nil {
+				t.Fatal(err)
+			}
+			after, err := loadTorrentFile(retracked)
+			if err != nil {
+				t.Fatal(err)
+			}
+			oldInfo, _ := before.unmarshalInfo()
+			newInfo, err := after.unmarshalInfo()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(oldInfo.Pieces, newInfo.Pieces) || !reflect.DeepEqual(before.PieceLayers, after.PieceLayers) {
+				t.Error("pièces modifiées par le retrack")
+			}
+			if newInfo.Source != "B" || newInfo.Private != nil {
+				t.Errorf("source = %q, private = %v", newInfo.Source, newInfo.Private)
+			}
+			if after.Announce != "udp://b.example:1337/announce" {
+				t.Errorf("announce = %q", after.Announce)
+			}
+			// Rien de propre au tracker A ne doit passer dans le torrent du tracker B
+			if after.Comment != NewGenerator().comment {
+				t.Errorf("comment = %q, commentaire du générateur attendu", after.Comment)
+			}
+			if len(after.UrlList) != 0 {
+				t.Errorf("web seeds = %v, aucun attendu", after.UrlList)
+			}
+
+			oldHashes, _ := GetInfoHash(original)
+			newHashes, _ := GetInfoHash(retracked)
+			if oldHashes == newHashes {
+				t.Error("l'infohash devrait changer")
+			}
+
+			report, err := Verify(context.Background(), retracked, data, 2, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !report.OK() {
+				t.Errorf("le torrent retracké ne valide pas les données: %+v", report)
+			}
+		})
+	}
+}
+
+func TestRetrackKeepsUnknownKeys(t *testing.T) {
+	original := filepath.Join("testdata", "bittorrent-v2-hybrid-test.torrent")
+	retracked := filepath.Join(t.TempDir(), "retracked.torrent")
+
+	profile := Profile{Name: "b", AnnounceList: [][]string{{"https://b.example/announce"}}, Source: "B", Private: true}
+	if err := NewGenerator().Retrack(original, retracked, profile); err != nil {
+		t.Fatal(err)
+	}
+
+	rawInfo := func(path string) map[string]bencode.Bytes {
+		tf, err := loadTorrentFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var raw map[string]bencode.Bytes
+		if err := bencode.Unmarshal(tf.InfoBytes, &raw); err != nil {
+			t.Fatal(err)
+		}
+		return raw
+	}
+
+	before, after := rawInfo(original), rawInfo(retracked)
+	if string(after["private"]) != "i1e" || string(after["source"]) != "1:B" {
+		t.Errorf("private = %s, source = %s", after["private"], after["source"])
+	}
+	delete(after, "private")
+	delete(after, "source")
+	delete(before, "private")
+	delete(before, "source")
+	if !reflect.DeepEqual(before, after) {
+		t.Error("le dictionnaire info a perdu ou modifié des clés")
+	}
+}
+
+func TestRetrackPieceSizeLimits(t *testing.T) {
+	dir := t.TempDir()
+	data := filepath.Join(dir, "movie.mkv")
+	writeRandomFile(t, data, 4096, 1)
+
+	original := filepath.Join(dir, "movie.torrent")
+	if err := NewGenerator().Create(context.Background(), data, original); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		min     int64
+		max     int64
+		wantErr bool
+	}{
+		{name: "Sans limite"},
+		{name: "Dans les limites", min: 256 << 10, max: 16 << 20},
+		{name: "Pièces trop petites", min: 4 << 20, wantErr: true},
+		{name: "Pièces trop grandes", max: 512 << 10, wantErr: true},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := filepath.Join(dir, string(rune('a'+i))+".torrent")
+			profile := Profile{Name: "b", Private: true, MinPieceSize: tt.min, MaxPieceSize: tt.max}
+			err := NewGenerator().Retrack(original, out, profile)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Retrack() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, statErr := os.Stat(out); tt.wantErr && statErr == nil {
+				t.Error("aucun torrent ne devrait être écrit en cas de refus")
+			}
+		})
+	}
+}
+
+func TestRetrackForProfilesChecksAll(t *testing.T) {
+	dir := t.TempDir()
+	data := filepath.Join(dir, "movie.mkv")
+	writeRandomFile(t, data, 4096, 1)
+
+	original := filepath.Join(dir, "movie.torrent")
+	if err := NewGenerator().Create(context.Background(), data, original); err != nil {
+		t.Fatal(err)
+	}
+
+	// Le deuxième profil est refusé: le premier torrent ne doit pas être écrit non plus
+	profiles := []Profile{{Name: "a", Private: true}, {Name: "b", Private: true, MinPieceSize: 4 << 20}}
+	outputs := []string{filepath.Join(dir, "a.torrent"), filepath.Join(dir, "b.torrent")}
+	if err := NewGenerator().RetrackForProfiles(original, profiles, outputs); err == nil {
+		t.Fatal("RetrackForProfiles() doit échouer")
+	}
+	for _, out := range outputs {
+		if _, err := os.Stat(out); err == nil {
+			t.Errorf("%s ne devrait pas être écrit", out)
+		}
+	}
+
+	profiles[1].MinPieceSize = 0
+	if err := NewGenerator().RetrackForProfiles(original, profiles, outputs); err != nil {
+		t.Fatal(err)
+	}
+	for _, out := range outputs {
+		if _, err := os.Stat(out); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestRetrackVersionMismatch(t *testing.T) {
+	dir := t.TempDir()
+	data := filepath.Join(dir, "movie.mkv")
+	writeRandomFile(t, data, 40000, 1)
+
+	originals := map[Version]string{}
+	for _, version := range []Version{VersionV1, VersionV2, VersionHybrid} {
+		g := NewGenerator()
+		g.SetVersion(version)
+		originals[version] = filepath.Join(dir, string(version)+".torrent")
+		if err := g.Create(context.Background(), data, originals[version]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for from, original := range originals {
+		for _, to := range []Version{"", VersionV1, VersionV2, VersionHybrid} {
+			out := filepath.Join(dir, string(from)+"-"+string(to)+".out.torrent")
+			profile := Profile{Name: "b", Private: true, Version: to}
+			err := NewGenerator().Retrack(original, out, profile)
+			wantErr := to != "" && to != from
+			if (err != nil) != wantErr {
+				t.Errorf("%s -> %q: Retrack() error = %v, wantErr %v", from, to, err, wantErr)
+			}
+			if _, statErr := os.Stat(out); wantErr && statErr == nil {
+				t.Errorf("%s -> %s: aucun torrent ne devrait être écrit en cas de refus", from, to)
+			}
+		}
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{input: "1048576", want: 1 << 20},
+		{input: "512KiB", want: 512 << 10},
+		{input: "16M", want: 16 << 20},
+		{input: "4 MB", want: 4 << 20},
+		{input: "1.5mib", want: 3 << 19},
+		{input: "2GiB", want: 2 << 30},
+		{input: "MiB", wantErr: true},
+		{input: "12 parsecs", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSize(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSize(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseSize(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
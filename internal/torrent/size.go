@@ -0,0 +1,55 @@
+package torrent
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// sizeUnits associe les suffixes acceptés à leur multiple (toujours binaire)
+var sizeUnits = map[string]int64{
+	"":    1,
+	"b":   1,
+	"k":   1 << 10,
+	"kb":  1 << 10,
+	"kib": 1 << 10,
+	"m":   1 << 20,
+	"mb":  1 << 20,
+	"mib": 1 << 20,
+	"g":   1 << 30,
+	"gb":  1 << 30,
+	"gib": 1 << 30,
+}
+
+// ParseSize convertit une taille lisible ("512KiB", "16M", "4 MB", "1048576") en octets.
+// Les unités sont toujours interprétées en puissances de 1024.
+func ParseSize(s string) (int64, error) {
+	raw := strings.ToLower(strings.TrimSpace(s))
+	i := strings.IndexFunc(raw, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if i < 0 {
+		i = len(raw)
+	}
+
+	unit, ok := sizeUnits[strings.TrimSpace(raw[i:])]
+	if !ok || i == 0 {
+		return 0, fmt.Errorf("taille invalide: %q", s)
+	}
+
+	value, err := strconv.ParseFloat(raw[:i], 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("taille invalide: %q", s)
+	}
+
+	return int64(value * float64(unit)), nil
+}
+
+// formatSize formate une taille en octets avec l'unité binaire la plus grande qui tombe juste
+func formatSize(size int64) string {
+	units := []string{"B", "KiB", "MiB", "GiB"}
+	exp := 0
+	for exp < len(units)-1 && size >= 1024 && size%1024 == 0 {
+		size /= 1024
+		exp++
+	}
+	return fmt.Sprintf("%d %s", size, units[exp])
+}
@@ -0,0 +1,153 @@
+package torrent
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"fmt"
+)
+
+// v2Hashes regroupe le résultat du hachage v2 (et v1 en mode hybride)
+type v2Hashes struct {
+	roots  [][]byte // pieces root par fichier (nil pour un fichier vide ou de bourrage)
+	layers [][]byte // piece layer par fichier (nil si le fichier tient dans une pièce)
+	pieces []byte   // pièces SHA-1 du flux aligné (mode hybride)
+}
+
+// pieceSpan associe une pièce du flux aligné à un fichier
+type pieceSpan struct {
+	file   int   // index du fichier dans la liste alignée
+	piece  int   // index de la pièce dans le fichier
+	length int64 // octets de données réelles (hors bourrage)
+}
+
+// alignFiles insère des fichiers de bourrage (BEP 47) pour que chaque fichier
+// commence sur une frontière de pièce, comme l'exigent les torrents hybrides
+func alignFiles(files []sourceFile, pieceLength int64) []sourceFile {
+	aligned := make([]sourceFile, 0, 2*len(files))
+	for i, f := range files {
+		aligned = append(aligned, f)
+		if i == len(files)-1 {
+			break
+		}
+		if rest := f.Length % pieceLength; rest != 0 {
+			aligned = append(aligned, paddingFile(pieceLength-rest))
+		}
+	}
+	return aligned
+}
+
+// hashV2 calcule les arbres de merkle v2 de fichiers alignés sur les pièces (voir alignFiles),
+// en une seule lecture séquentielle. En mode hybride, les pièces SHA-1 v1 sont calculées en même temps.
+func hashV2(ctx context.Context, files []sourceFile, pieceLength int64, workers int, hybrid bool, progress ProgressFunc) (*v2Hashes, error) {
+	h, err := newV2Hasher(files, pieceLength, hybrid)
+	if err != nil {
+		return nil, err
+	}
+	if err := readPieces(ctx, files, pieceLength, workers, progress, nil, h.hash); err != nil {
+		return nil, err
+	}
+	return h.finish(), nil
+}
+
+// v2Hasher calcule les arbres de merkle v2 (et les pièces v1 en mode hybride) pièce par pièce
+type v2Hasher struct {
+	files       []sourceFile
+	pieceLength int64
+	hybrid      bool
+	spans       []pieceSpan
+	result      *v2Hashes
+	leaves      [][][sha256.Size]byte // blocs des fichiers tenant dans une pièce
+}
+
+// newV2Hasher prépare le calcul v2 de fichiers alignés sur les pièces (voir alignFiles)
+func newV2Hasher(files []sourceFile, pieceLength int64, hybrid bool) (*v2Hasher, error) {
+	if pieceLength < blockSize || pieceLength&(pieceLength-1) != 0 {
+		return nil, fmt.Errorf("taille de pièce invalide pour un torrent v2: %d (puissance de deux >= 16 KiB requise)", pieceLength)
+	}
+
+	var spans []pieceSpan
+	for i, f := range files {
+		if f.Padding {
+			continue
+		}
+		for j := int64(0); j*pieceLength < f.Length; j++ {
+			spans = append(spans, pieceSpan{
+				file:   i,
+				piece:  int(j),
+				length: min(pieceLength, f.Length-j*pieceLength),
+			})
+		}
+	}
+
+	if numPieces := (streamLength(files) + pieceLength - 1) / pieceLength; numPieces != int64(len(spans)) {
+		return nil, fmt.Errorf("fichiers non alignés sur les pièces")
+	}
+
+	h := &v2Hasher{
+		files:       files,
+		pieceLength: pieceLength,
+		hybrid:      hybrid,
+		spans:       spans,
+		result: &v2Hashes{
+			roots:  make([][]byte, len(files)),
+			layers: make([][]byte, len(files)),
+		},
+		leaves: make([][][sha256.Size]byte, len(files)),
+	}
+	if hybrid {
+		h.result.pieces = make([]byte, len(spans)*sha1.Size)
+	}
+
+	// Les fichiers tenant dans une pièce gardent leurs blocs, les autres un nœud par pièce
+	for i, f := range files {
+		if !f.Padding && f.Length > pieceLength {
+			numPieces := (f.Length + pieceLength - 1) / pieceLength
+			h.result.layers[i] = make([]byte, numPieces*sha256.Size)
+		}
+	}
+	return h, nil
+}
+
+// hash traite une pièce du flux aligné
+func (h *v2Hasher) hash(index int, data []byte) {
+	span := h.spans[index]
+
+	blocks := blockHashes(data[:span.length])
+	if layer := h.result.layers[span.file]; layer != nil {
+		node := pieceLayerNode(blocks, h.pieceLength)
+		copy(layer[span.piece*sha256.Size:], node[:])
+	} else {
+		h.leaves[span.file] = blocks
+	}
+
+	// La pièce v1 inclut le bourrage qui suit le fichier
+	if h.hybrid {
+		sum := sha1.Sum(data)
+		copy(h.result.pieces[index*sha1.Size:], sum[:])
+	}
+}
+
+// finish calcule les racines des arbres une fois toutes les pièces traitées
+func (h *v2Hasher) finish() *v2Hashes {
+	padHash := piecePadHash(h.pieceLength)
+	for i, f := range h.files {
+		if f.Padding || f.Length == 0 {
+			continue
+		}
+
+		var root [sha256.Size]byte
+		if layer := h.result.layers[i]; layer != nil {
+			nodes := make([][sha256.Size]byte, len(layer)/sha256.Size)
+			for j := range nodes {
+				copy(nodes[j][:], layer[j*sha256.Size:])
+			}
+			root = merkleRoot(nodes, padHash)
+		} else {
+			root = merkleRoot(h.leaves[i], [sha256.Size]byte{})
+		}
+		h.result.roots[i] = root[:]
+	}
+
+	return h.result
+}
@@ -0,0 +1,243 @@
+package torrent
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/anacrolix/torrent/metainfo"
+)
+
+// Vecteurs de référence calculés avec le paquet merkle de github.com/anacrolix/torrent v1.59.1
+// pour des données où l'octet i vaut i % 251, avec des pièces de 32 KiB.
+var merkleVectors = []struct {
+	size  int
+	root  string
+	layer string
+}{
+	{size: 1, root: "6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d"},
+	{size: 16384, root: "4348e3b98e8a327b34ced39c1da9e67cdb4cd5e48e4d7960607a3ae403d35f0c"},
+	{size: 16385, root: "9d7887c65d577a0237fb3c0998b87b3a62762d03796889a2caea01db914ccbb8"},
+	{size: 32768, root: "d9e13d0b676ad681164ef0b7b5910d1328ea83a047cad57e619d76bbe3a08525"},
+	{
+		size: 100000,
+		root: "505fc9a922f60ae071450b07256a4ba760612bffc38c27584ed03fd96c69841b",
+		layer: "d9e13d0b676ad681164ef0b7b5910d1328ea83a047cad57e619d76bbe3a08525" +
+			"e28097eaaa55956702cf8195d1a551dbabb63e3d679b294cf33d506a6b5ef479" +
+			"c652249676984ba0be8db1d26efa9e0c67cd14299b02eaab326419a0f91a1aec" +
+			"ef72b5ef0b29bacc8de6bc437f8a488da617d064457122b7db2c4092374151f9",
+	},
+}
+
+func patternData(size int) []byte {
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	return data
+}
+
+func TestPiecePadHash(t *testing.T) {
+	const want = "f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a92759fb4b"
+	pad := piecePadHash(32 * 1024)
+	if got := hex.EncodeToString(pad[:]); got != want {
+		t.Errorf("piecePadHash() = %s, want %s", got, want)
+	}
+}
+
+func TestHashV2Vectors(t *testing.T) {
+	const pieceLength = 32 * 1024
+	dir := t.TempDir()
+
+	for _, tt := range merkleVectors {
+		t.Run(strconv.Itoa(tt.size), func(t *testing.T) {
+			path := filepath.Join(dir, strconv.Itoa(tt.size))
+			if err := os.WriteFile(path, patternData(tt.size), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			files := []sourceFile{{Path: path, Length: int64(tt.size)}}
+			hashes, err := hashV2(context.Background(), files, pieceLength, 3, false, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := hex.EncodeToString(hashes.roots[0]); got != tt.root {
+				t.Errorf("pieces root = %s, want %s", got, tt.root)
+			}
+			if got := hex.EncodeToString(hashes.layers[0]); got != tt.layer {
+				t.Errorf("piece layer = %s, want %s", got, tt.layer)
+			}
+		})
+	}
+}
+
+// Torrents de test publiés avec libtorrent (https://blog.libtorrent.org/2020/09/bittorrent-v2/)
+func TestReferenceTorrents(t *testing.T) {
+	tests := []struct {
+		file   string
+		v1, v2 string
+	}{
+		{
+			file: "bittorrent-v2-test.torrent",
+			v2:   "caf1e1c30e81cb361b9ee167c4aa64228a7fa4fa9f6105232b28ad099f3a302e",
+		},
+		{
+			file: "bittorrent-v2-hybrid-test.torrent",
+			v1:   "631a31dd0a46257d5078c0dee4e66e26f73e42ac",
+			v2:   "d8dd32ac93357c368556af3ac1d95c9d76bd0dff6fa9833ecdac3d53134efabb",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			path := filepath.Join("testdata", tt.file)
+
+			hashes, err := GetInfoHash(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hashes.V1 != tt.v1 || hashes.V2 != tt.v2 {
+				t.Errorf("GetInfoHash() = %+v, want v1=%s v2=%s", hashes, tt.v1, tt.v2)
+			}
+
+			tf, err := loadTorrentFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			info, err := tf.unmarshalInfo()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Chaque piece layer doit redonner le pieces root du fichier
+			padHash := piecePadHash(info.PieceLength)
+			info.FileTree.walk(nil, func(path []string, file fileTreeFile) {
+				if file.Length <= info.PieceLength {
+					return
+				}
+				layer, ok := tf.PieceLayers[string(file.PiecesRoot)]
+				if !ok {
+					t.Errorf("%s: piece layer manquante", strings.Join(path, "/"))
+					return
+				}
+				nodes := make([][sha256.Size]byte, len(layer)/sha256.Size)
+				for i := range nodes {
+					copy(nodes[i][:], layer[i*sha256.Size:])
+				}
+				if root := merkleRoot(nodes, padHash); string(root[:]) != string(file.PiecesRoot) {
+					t.Errorf("%s: racine %x, want %x", strings.Join(path, "/"), root, file.PiecesRoot)
+				}
+			})
+		})
+	}
+}
+
+func TestCreateHybridLayout(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "Release")
+	writeRandomFile(t, filepath.Join(root, "movie.mkv"), 3*1024*1024+123, 1)
+	writeRandomFile(t, filepath.Join(root, "release.nfo"), 2000, 2)
+	writeRandomFile(t, filepath.Join(root, "Subs", "fr.srt"), 40000, 3)
+
+	for _, version := range []Version{VersionV2, VersionHybrid} {
+		t.Run(string(version), func(t *testing.T) {
+			g := NewGenerator()
+			g.SetVersion(version)
+			out := filepath.Join(dir, string(version)+".torrent")
+			if err := g.CreateFromDirectory(context.Background(), root, out); err != nil {
+				t.Fatal(err)
+			}
+
+			tf, err := loadTorrentFile(out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			info, err := tf.unmarshalInfo()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.MetaVersion != 2 || info.FileTree == nil {
+				t.Fatal("meta version ou file tree manquant")
+			}
+
+			hashes, err := GetInfoHash(out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hashes.V2 == "" || (hashes.V1 != "") != (version == VersionHybrid) {
+				t.Errorf("GetInfoHash() = %+v", hashes)
+			}
+
+			// Le fichier vidéo (plusieurs pièces) doit avoir une piece layer
+			var treeFiles [][]string
+			info.FileTree.walk(nil, func(path []string, file fileTreeFile) {
+				treeFiles = append(treeFiles, path)
+				if file.Length > info.PieceLength {
+					if _, ok := tf.PieceLayers[string(file.PiecesRoot)]; !ok {
+						t.Errorf("%v: piece layer manquante", path)
+					}
+				}
+			})
+
+			if version != VersionHybrid {
+				if len(info.Files) != 0 || len(info.Pieces) != 0 {
+					t.Error("un torrent v2 ne doit pas contenir de champs v1")
+				}
+				return
+			}
+
+			// En hybride, la liste v1 suit l'ordre du file tree avec des fichiers de bourrage
+			var offset int64
+			var v1Files [][]string
+			for i, f := range info.Files {
+				if f.Attr == "p" {
+					if offset%info.PieceLength == 0 {
+						t.Errorf("bourrage inutile à l'index %d", i)
+					}
+				} else {
+					if offset%info.PieceLength != 0 {
+						t.Errorf("%v ne commence pas sur une frontière de pièce", f.Path)
+					}
+					v1Files = append(v1Files, f.Path)
+				}
+				offset += f.Length
+			}
+			if len(v1Files) != len(treeFiles) {
+				t.Fatalf("%d fichiers v1, %d dans le file tree", len(v1Files), len(treeFiles))
+			}
+			for i := range v1Files {
+				if strings.Join(v1Files[i], "/") != strings.Join(treeFiles[i], "/") {
+					t.Errorf("fichier %d: %v, want %v", i, v1Files[i], treeFiles[i])
+				}
+			}
+
+			// Les pièces v1 couvrent les données et les zéros de bourrage
+			var stream []byte
+			for _, f := range info.Files {
+				if f.Attr == "p" {
+					stream = append(stream, make([]byte, f.Length)...)
+					continue
+				}
+				data, err := os.ReadFile(filepath.Join(append([]string{root}, f.Path...)...))
+				if err != nil {
+					t.Fatal(err)
+				}
+				stream = append(stream, data...)
+			}
+			want, err := metainfo.GeneratePieces(bytes.NewReader(stream), info.PieceLength, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(info.Pieces, want) {
+				t.Error("pièces v1 différentes du hachage séquentiel du flux aligné")
+			}
+		})
+	}
+}
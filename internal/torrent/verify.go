@@ -0,0 +1,250 @@
+package torrent
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// FileState représente l'état d'un fichier après vérification
+type FileState string
+
+const (
+	// FileOK indique un fichier complet dont toutes les pièces sont valides
+	FileOK FileState = "ok"
+	// FileMissing indique un fichier absent du disque
+	FileMissing FileState = "missing"
+	// FileShort indique un fichier plus court que prévu
+	FileShort FileState = "short"
+	// FileCorrupt indique un fichier de mauvaise taille ou avec des pièces invalides
+	FileCorrupt FileState = "corrupt"
+)
+
+// FileReport contient le résultat de la vérification d'un fichier
+type FileReport struct {
+	Path      string    `json:"path"`
+	Length    int64     `json:"length"`
+	Size      int64     `json:"size"`
+	State     FileState `json:"state"`
+	BadPieces int       `json:"bad_pieces"`
+}
+
+// VerifyReport contient le résultat de la vérification de données par rapport à un torrent
+type VerifyReport struct {
+	Torrent     string       `json:"torrent"`
+	DataPath    string       `json:"data_path"`
+	InfoHash    InfoHashes   `json:"info_hash"`
+	PieceLength int64        `json:"piece_length"`
+	Pieces      int          `json:"pieces"`
+	ValidPieces int          `json:"valid_pieces"`
+	Percent     float64      `json:"percent"`
+	Files       []FileReport `json:"files"`
+}
+
+// OK indique si les données correspondent entièrement au torrent
+func (r *VerifyReport) OK() bool {
+	if r.ValidPieces != r.Pieces {
+		return false
+	}
+	for _, f := range r.Files {
+		if f.State != FileOK {
+			return false
+		}
+	}
+	return true
+}
+
+// Verify vérifie les données présentes sur disque par rapport à un fichier torrent.
+// dataPath désigne les données elles-mêmes ou le dossier qui les contient.
+func Verify(ctx context.Context, torrentPath, dataPath string, workers int, progress ProgressFunc) (*VerifyReport, error) {
+	tf, err := loadTorrentFile(torrentPath)
+	if err != nil {
+		return nil, err
+	}
+	info, err := tf.unmarshalInfo()
+	if err != nil {
+		return nil, err
+	}
+	hashes, err := computeInfoHashes(tf.InfoBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	root := resolveDataRoot(&info, dataPath)
+	files, trees := info.torrentFiles(root)
+
+	report := &VerifyReport{
+		Torrent:     torrentPath,
+		DataPath:    root,
+		InfoHash:    hashes,
+		PieceLength: info.PieceLength,
+	}
+
+	// État des fichiers sur disque; les données absentes sont lues comme des zéros
+	fileReports := make([]*FileReport, len(files))
+	for i := range files {
+		if files[i].Padding {
+			continue
+		}
+		files[i].Lenient = true
+
+		fr := &FileReport{
+			Path:   displayPath(&info, files[i]),
+			Length: files[i].Length,
+			State:  FileOK,
+		}
+		stat, err := os.Stat(files[i].Path)
+		switch {
+		case err != nil:
+			fr.State = FileMissing
+		case stat.Size() < files[i].Length:
+			fr.Size = stat.Size()
+			fr.State = FileShort
+		case stat.Size() > files[i].Length:
+			fr.Size = stat.Size()
+			fr.State = FileCorrupt
+		default:
+			fr.Size = stat.Size()
+		}
+		fileReports[i] = fr
+	}
+
+	if info.isV1() {
+		err = verifyV1(ctx, &info, files, fileReports, report, workers, progress)
+	} else {
+		err = verifyV2(ctx, &info, tf.PieceLayers, files, trees, fileReports, report, workers, progress)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fr := range fileReports {
+		if fr == nil {
+			continue
+		}
+		if fr.State == FileOK && fr.BadPieces > 0 {
+			fr.State = FileCorrupt
+		}
+		report.Files = append(report.Files, *fr)
+	}
+
+	report.Percent = 100
+	if report.Pieces > 0 {
+		report.Percent = float64(report.ValidPieces) / float64(report.Pieces) * 100
+	}
+
+	return report, nil
+}
+
+// verifyV1 vérifie les pièces SHA-1 du flux de données (torrents v1 et hybrides)
+func verifyV1(ctx context.Context, info *infoDict, files []sourceFile, fileReports []*FileReport, report *VerifyReport, workers int, progress ProgressFunc) error {
+	numPieces := len(info.Pieces) / sha1.Size
+	if expected := (streamLength(files) + info.PieceLength - 1) / info.PieceLength; int64(numPieces) != expected {
+		return fmt.Errorf("torrent invalide: %d pièces pour %d attendues", numPieces, expected)
+	}
+
+	valid := make([]bool, numPieces)
+	err := readPieces(ctx, files, info.PieceLength, workers, progress, nil, func(index int, data []byte) {
+		sum := sha1.Sum(data)
+		valid[index] = bytes.Equal(sum[:], info.Pieces[index*sha1.Size:(index+1)*sha1.Size])
+	})
+	if err != nil {
+		return err
+	}
+
+	report.Pieces = numPieces
+	for _, ok := range valid {
+		if ok {
+			report.ValidPieces++
+		}
+	}
+
+	// Attribuer chaque pièce invalide aux fichiers qu'elle recouvre. Une pièce partagée avec un
+	// fichier manquant ou tronqué n'est imputée qu'à ce dernier, les autres fichiers étant intacts.
+	overlaps := make([][]int, numPieces)
+	var offset int64
+	for i, f := range files {
+		if fileReports[i] != nil && f.Length > 0 {
+			first := offset / info.PieceLength
+			last := (offset + f.Length - 1) / info.PieceLength
+			for p := first; p <= last; p++ {
+				overlaps[p] = append(overlaps[p], i)
+			}
+		}
+		offset += f.Length
+	}
+
+	for p, ok := range valid {
+		if ok {
+			continue
+		}
+		var damaged []int
+		for _, i := range overlaps[p] {
+			if fileReports[i].State != FileOK {
+				damaged = append(damaged, i)
+			}
+		}
+		if len(damaged) == 0 {
+			damaged = overlaps[p]
+		}
+		for _, i := range damaged {
+			fileReports[i].BadPieces++
+		}
+	}
+
+	return nil
+}
+
+// verifyV2 vérifie les arbres de merkle de chaque fichier (torrents v2 sans pièces v1)
+func verifyV2(ctx context.Context, info *infoDict, pieceLayers map[string]string, files []sourceFile, trees []fileTreeFile, fileReports []*FileReport, report *VerifyReport, workers int, progress ProgressFunc) error {
+	hashes, err := hashV2(ctx, files, info.PieceLength, workers, false, progress)
+	if err != nil {
+		return err
+	}
+
+	for i, f := range files {
+		if f.Padding || f.Length == 0 {
+			continue
+		}
+
+		numPieces := int((f.Length + info.PieceLength - 1) / info.PieceLength)
+		report.Pieces += numPieces
+
+		if f.Length <= info.PieceLength {
+			// Fichier d'une seule pièce: seul le pieces root fait foi
+			if bytes.Equal(hashes.roots[i], trees[i].PiecesRoot) {
+				report.ValidPieces++
+			} else {
+				fileReports[i].BadPieces++
+			}
+			continue
+		}
+
+		layer, ok := pieceLayers[string(trees[i].PiecesRoot)]
+		if !ok || len(layer) != numPieces*sha256.Size {
+			return fmt.Errorf("torrent invalide: piece layer manquante pour %s", fileReports[i].Path)
+		}
+		for p := 0; p < numPieces; p++ {
+			start, end := p*sha256.Size, (p+1)*sha256.Size
+			if bytes.Equal(hashes.layers[i][start:end], []byte(layer[start:end])) {
+				report.ValidPieces++
+			} else {
+				fileReports[i].BadPieces++
+			}
+		}
+	}
+
+	return nil
+}
+
+// displayPath retourne le chemin d'un fichier tel qu'affiché dans les rapports
+func displayPath(info *infoDict, f sourceFile) string {
+	if f.Parts == nil {
+		return info.Name
+	}
+	return strings.Join(f.Parts, "/")
+}
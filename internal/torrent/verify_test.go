@@ -0,0 +1,168 @@
+package torrent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anacrolix/torrent/bencode"
+)
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name   string
+		damage func(t *testing.T, root string)
+		want   map[string]FileState
+	}{
+		{
+			name:   "Données intactes",
+			damage: func(t *testing.T, root string) {},
+			want:   map[string]FileState{"movie.mkv": FileOK, "release.nfo": FileOK, "Subs/fr.srt": FileOK},
+		},
+		{
+			name: "Octet modifié",
+			damage: func(t *testing.T, root string) {
+				path := filepath.Join(root, "movie.mkv")
+				data, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				data[len(data)/2] ^= 0xff
+				if err := os.WriteFile(path, data, 0644); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: map[string]FileState{"movie.mkv": FileCorrupt, "release.nfo": FileOK, "Subs/fr.srt": FileOK},
+		},
+		{
+			name: "Fichier manquant",
+			damage: func(t *testing.T, root string) {
+				if err := os.Remove(filepath.Join(root, "release.nfo")); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: map[string]FileState{"movie.mkv": FileOK, "release.nfo": FileMissing, "Subs/fr.srt": FileOK},
+		},
+		{
+			name: "Fichier tronqué",
+			damage: func(t *testing.T, root string) {
+				if err := os.Truncate(filepath.Join(root, "Subs", "fr.srt"), 100); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: map[string]FileState{"movie.mkv": FileOK, "release.nfo": FileOK, "Subs/fr.srt": FileShort},
+		},
+	}
+
+	for _, version := range []Version{VersionV1, VersionV2, VersionHybrid} {
+		for _, tt := range tests {
+			t.Run(string(version)+"/"+tt.name, func(t *testing.T) {
+				dir := t.TempDir()
+				root := filepath.Join(dir, "Release")
+				writeRandomFile(t, filepath.Join(root, "movie.mkv"), 5*1024*1024+17, 1)
+				writeRandomFile(t, filepath.Join(root, "release.nfo"), 2000, 2)
+				writeRandomFile(t, filepath.Join(root, "Subs", "fr.srt"), 40000, 3)
+
+				g := NewGenerator()
+				g.SetVersion(version)
+				torrentPath := filepath.Join(dir, "release.torrent")
+				if err := g.CreateFromDirectory(context.Background(), root, torrentPath); err != nil {
+					t.Fatal(err)
+				}
+
+				tt.damage(t, root)
+
+				// Le chemin peut être le dossier parent des données
+				report, err := Verify(context.Background(), torrentPath, dir, 2, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if report.DataPath != root {
+					t.Errorf("DataPath = %s, want %s", report.DataPath, root)
+				}
+				for _, f := range report.Files {
+					if want := tt.want[f.Path]; f.State != want {
+						t.Errorf("%s: état %s, want %s", f.Path, f.State, want)
+					}
+				}
+				if len(report.Files) != len(tt.want) {
+					t.Errorf("%d fichiers, want %d", len(report.Files), len(tt.want))
+				}
+
+				intact := tt.name == "Données intactes"
+				if report.OK() != intact {
+					t.Errorf("OK() = %v, want %v", report.OK(), intact)
+				}
+				if intact && report.Percent != 100 {
+					t.Errorf("Percent = %.1f, want 100", report.Percent)
+				}
+				if !intact && report.ValidPieces == report.Pieces {
+					t.Error("aucune pièce invalide détectée")
+				}
+			})
+		}
+	}
+}
+
+func TestVerifySingleFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "movie.mkv")
+	writeRandomFile(t, path, 3*1024*1024, 1)
+
+	for _, version := range []Version{VersionV1, VersionV2, VersionHybrid} {
+		g := NewGenerator()
+		g.SetVersion(version)
+		torrentPath := filepath.Join(dir, string(version)+".torrent")
+		if err := g.Create(context.Background(), path, torrentPath); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, dataPath := range []string{path, dir} {
+			report, err := Verify(context.Background(), torrentPath, dataPath, 2, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !report.OK() || len(report.Files) != 1 || report.Files[0].Path != "movie.mkv" {
+				t.Errorf("%s (%s): rapport %+v", version, dataPath, report)
+			}
+		}
+	}
+}
+
+// writeZeroPieceLengthTorrent écrit un torrent malformé dont le dictionnaire info a un piece length nul
+func writeZeroPieceLengthTorrent(t *testing.T, dir string) string {
+	t.Helper()
+	data, err := bencode.Marshal(map[string]any{
+		"announce": "https://tracker.example/announce",
+		"info": map[string]any{
+			"name":         "movie.mkv",
+			"length":       1024,
+			"piece length": 0,
+			"pieces":       "",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "malformed.torrent")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestVerifyZeroPieceLength(t *testing.T) {
+	dir := t.TempDir()
+	torrentPath := writeZeroPieceLengthTorrent(t, dir)
+	writeRandomFile(t, filepath.Join(dir, "movie.mkv"), 1024, 1)
+
+	if _, err := Verify(context.Background(), torrentPath, dir, 2, nil); err == nil || !strings.Contains(err.Error(), "piece length") {
+		t.Errorf("Verify() error = %v, want piece length invalide", err)
+	}
+	if _, err := Inspect(torrentPath); err == nil {
+		t.Error("Inspect() doit refuser un piece length nul")
+	}
+}
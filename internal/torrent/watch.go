@@ -0,0 +1,123 @@
+package torrent
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// ExportToWatchDir copie un torrent dans le dossier surveillé d'un client.
+// Le fichier est écrit sous un nom temporaire puis renommé, pour que le client ne lise
+// jamais un fichier incomplet. La copie est sautée (copied = false) si un torrent de même
+// infohash est déjà présent dans le dossier; un autre torrent du même nom n'est jamais écrasé.
+func ExportToWatchDir(torrentPath, watchDir string) (copied bool, err error) {
+	hashes, err := GetInfoHash(torrentPath)
+	if err != nil {
+		return false, err
+	}
+
+	if err := os.MkdirAll(watchDir, 0755); err != nil {
+		return false, fmt.Errorf("erreur création dossier surveillé: %w", err)
+	}
+
+	existing, err := findInfoHash(watchDir, hashes)
+	if err != nil {
+		return false, err
+	}
+	if existing != "" {
+		return false, nil
+	}
+	// Même nom mais autre infohash (autre version de la release): le client l'a peut-être déjà chargé
+	target := filepath.Join(watchDir, filepath.Base(torrentPath))
+	if _, err := os.Lstat(target); err == nil {
+		return false, fmt.Errorf("un autre torrent %s existe déjà dans %s", filepath.Base(torrentPath), watchDir)
+	}
+
+	src, err := os.Open(torrentPath)
+	if err != nil {
+		return false, fmt.Errorf("erreur lecture torrent: %w", err)
+	}
+	defer src.Close()
+
+	// Nom caché sans extension .torrent: ignoré par les clients qui surveillent le dossier
+	tmp, err := os.CreateTemp(watchDir, "."+filepath.Base(torrentPath)+".*.tmp")
+	if err != nil {
+		return false, fmt.Errorf("erreur création fichier temporaire: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, src); err != nil {
+		tmp.Close()
+		return false, fmt.Errorf("erreur copie torrent: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return false, fmt.Errorf("erreur copie torrent: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return false, fmt.Errorf("erreur copie torrent: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return false, fmt.Errorf("erreur copie torrent: %w", err)
+	}
+
+	// Link échoue si la cible est apparue entre-temps, au lieu de l'écraser comme Rename.
+	// Rename reste le repli sur les systèmes de fichiers sans liens physiques, après une
+	// dernière vérification de la cible.
+	if err := os.Link(tmp.Name(), target); os.IsExist(err) {
+		return false, fmt.Errorf("un autre torrent %s existe déjà dans %s", filepath.Base(torrentPath), watchDir)
+	} else if err != nil {
+		if !linkUnsupported(err) {
+			return false, fmt.Errorf("erreur copie torrent: %w", err)
+		}
+		if _, err := os.Lstat(target); err == nil {
+			return false, fmt.Errorf("un autre torrent %s existe déjà dans %s", filepath.Base(torrentPath), watchDir)
+		}
+		if err := os.Rename(tmp.Name(), target); err != nil {
+			return false, fmt.Errorf("erreur copie torrent: %w", err)
+		}
+	}
+
+	return true, nil
+}
+
+// linkUnsupported indique si os.Link a échoué parce que le système de fichiers ne prend pas
+// en charge les liens physiques (FAT, certains partages réseau), et non à cause de la cible
+func linkUnsupported(err error) bool {
+	var linkErr *os.LinkError
+	if !errors.As(err, &linkErr) {
+		return false
+	}
+	return errors.Is(linkErr.Err, syscall.EXDEV) || errors.Is(linkErr.Err, syscall.EPERM) || errors.Is(linkErr.Err, errors.ErrUnsupported)
+}
+
+// findInfoHash cherche dans un dossier un torrent ayant l'un des infohashes donnés.
+// Les fichiers renommés par les clients après chargement (.torrent.added, .torrent.loaded...) sont pris en compte.
+func findInfoHash(dir string, hashes InfoHashes) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("erreur lecture dossier surveillé: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.Contains(entry.Name(), ".torrent") || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		other, err := GetInfoHash(path)
+		if err != nil {
+			// Fichier illisible ou en cours d'écriture par un autre outil
+			continue
+		}
+		if (hashes.V1 != "" && other.V1 == hashes.V1) || (hashes.V2 != "" && other.V2 == hashes.V2) {
+			return path, nil
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,99 @@
+package torrent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func TestExportToWatchDir(t *testing.T) {
+	dir := t.TempDir()
+	data := filepath.Join(dir, "movie.mkv")
+	writeRandomFile(t, data, 50000, 1)
+
+	torrentPath := filepath.Join(dir, "movie.torrent")
+	if err := NewGenerator().Create(context.Background(), data, torrentPath); err != nil {
+		t.Fatal(err)
+	}
+	original, err := os.ReadFile(torrentPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	watchDir := filepath.Join(dir, "watch", "profil")
+	copied, err := ExportToWatchDir(torrentPath, watchDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !copied {
+		t.Fatal("le torrent devrait être copié")
+	}
+
+	exported, err := os.ReadFile(filepath.Join(watchDir, "movie.torrent"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(exported) != string(original) {
+		t.Error("contenu copié différent")
+	}
+
+	// Un client a chargé puis renommé le fichier: même infohash, autre nom
+	if err := os.Rename(filepath.Join(watchDir, "movie.torrent"), filepath.Join(watchDir, "movie.torrent.added")); err != nil {
+		t.Fatal(err)
+	}
+	copied, err = ExportToWatchDir(torrentPath, watchDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if copied {
+		t.Error("un torrent de même infohash est déjà présent: la copie devrait être sautée")
+	}
+
+	// Même nom, autre infohash: le torrent en place n'est pas écrasé
+	other := filepath.Join(dir, "other", "movie.torrent")
+	os.MkdirAll(filepath.Dir(other), 0755)
+	g := NewGenerator()
+	g.SetSource("AUTRE")
+	if err := g.Create(context.Background(), data, other); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(watchDir, "movie.torrent"), original, 0644)
+	os.Remove(filepath.Join(watchDir, "movie.torrent.added"))
+	if copied, err := ExportToWatchDir(other, watchDir); err == nil || copied {
+		t.Errorf("copied = %v, err = %v: un torrent différent du même nom ne doit pas être écrasé", copied, err)
+	}
+	if kept, _ := os.ReadFile(filepath.Join(watchDir, "movie.torrent")); string(kept) != string(original) {
+		t.Error("le torrent en place a été écrasé")
+	}
+
+	entries, err := os.ReadDir(watchDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("fichiers dans le dossier surveillé: %v (fichier temporaire oublié ?)", entries)
+	}
+}
+
+func TestLinkUnsupported(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&os.LinkError{Op: "link", Err: syscall.EXDEV}, true},
+		{&os.LinkError{Op: "link", Err: syscall.EPERM}, true},
+		{&os.LinkError{Op: "link", Err: syscall.ENOTSUP}, true},
+		// Une cible existante ou un dossier absent ne doivent pas mener à Rename
+		{&os.LinkError{Op: "link", Err: syscall.EEXIST}, false},
+		{&os.LinkError{Op: "link", Err: syscall.ENOENT}, false},
+		{syscall.EXDEV, false},
+	}
+
+	for _, tt := range tests {
+		if got := linkUnsupported(tt.err); got != tt.want {
+			t.Errorf("linkUnsupported(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
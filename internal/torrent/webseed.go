@@ -0,0 +1,71 @@
+package torrent
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+)
+
+// WebSeedData contient les variables disponibles dans les modèles d'URL de web seed
+type WebSeedData struct {
+	Name    string // nom de release (renamer.GenerateName)
+	File    string // nom du fichier ou dossier du torrent
+	Profile string // profil de tracker, vide hors profil
+}
+
+// webSeedFuncs sont les fonctions disponibles dans les modèles d'URL de web seed
+var webSeedFuncs = template.FuncMap{
+	"pathescape": url.PathEscape,
+}
+
+// ExpandWebSeeds applique les modèles d'URL (text/template, ex: https://seed/{{.Name}}/)
+// et valide les URLs obtenues (BEP 19)
+func ExpandWebSeeds(templates []string, data WebSeedData) ([]string, error) {
+	var urls []string
+
+	for _, tmpl := range templates {
+		tmpl = strings.TrimSpace(tmpl)
+		if tmpl == "" {
+			continue
+		}
+
+		t, err := template.New("web-seed").Funcs(webSeedFuncs).Option("missingkey=error").Parse(tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("modèle de web seed invalide %q: %w", tmpl, err)
+		}
+
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("modèle de web seed invalide %q: %w", tmpl, err)
+		}
+
+		if err := ValidateWebSeedURL(buf.String()); err != nil {
+			return nil, err
+		}
+		urls = append(urls, buf.String())
+	}
+
+	return urls, nil
+}
+
+// ValidateWebSeedURL vérifie qu'une URL de web seed est une URL HTTP(S) valide
+func ValidateWebSeedURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("URL de web seed invalide %q: %w", raw, err)
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+	default:
+		return fmt.Errorf("URL de web seed invalide %q: schéma %q non supporté", raw, u.Scheme)
+	}
+
+	if u.Host == "" {
+		return fmt.Errorf("URL de web seed invalide %q: hôte manquant", raw)
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package torrent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/anacrolix/torrent/metainfo"
+)
+
+func TestExpandWebSeeds(t *testing.T) {
+	single := WebSeedData{Name: "Film.2024.1080p-GRP", File: "Film.2024.1080p-GRP.mkv"}
+	// Saison ou manifeste: le torrent est un dossier, File est vide
+	multi := WebSeedData{Name: "Serie.S01.COMPLETE-GRP", Profile: "monTracker"}
+
+	tests := []struct {
+		name      string
+		templates []string
+		data      WebSeedData
+		want      []string
+		wantErr   string
+	}{
+		{
+			name:      "URL fixe",
+			templates: []string{"https://seed.example/films/"},
+			data:      single,
+			want:      []string{"https://seed.example/films/"},
+		},
+		{
+			name:      "Fichier unique",
+			templates: []string{"https://seed.example/{{.Name}}/{{.File}}"},
+			data:      single,
+			want:      []string{"https://seed.example/Film.2024.1080p-GRP/Film.2024.1080p-GRP.mkv"},
+		},
+		{
+			name:      "Multi-fichiers",
+			templates: []string{"https://seed.example/{{.Profile}}/{{.Name}}/", " ", "http://mirror.example/{{.Name}}/"},
+			data:      multi,
+			want:      []string{"https://seed.example/monTracker/Serie.S01.COMPLETE-GRP/", "http://mirror.example/Serie.S01.COMPLETE-GRP/"},
+		},
+		{
+			name:      "Échappement",
+			templates: []string{"https://seed.example/{{pathescape .Name}}/"},
+			data:      WebSeedData{Name: "Film (2024) #1"},
+			want:      []string{"https://seed.example/Film%20%282024%29%20%231/"},
+		},
+		{
+			name:      "Aucun modèle",
+			templates: nil,
+			data:      single,
+			want:      nil,
+		},
+		{
+			name:      "Champ inconnu",
+			templates: []string{"https://seed.example/{{.Release}}/"},
+			data:      single,
+			wantErr:   "modèle de web seed invalide",
+		},
+		{
+			name:      "Modèle mal formé",
+			templates: []string{"https://seed.example/{{.Name/"},
+			data:      single,
+			wantErr:   "modèle de web seed invalide",
+		},
+		{
+			name:      "Schéma invalide après expansion",
+			templates: []string{"ftp://seed.example/{{.Name}}/"},
+			data:      multi,
+			wantErr:   "schéma",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandWebSeeds(tt.templates, tt.data)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("erreur = %v, attendu %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("web seeds = %q, attendu %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateWebSeedURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr string
+	}{
+		{"http://seed.example/release/", ""},
+		{"https://seed.example/release/film.mkv", ""},
+		{"HTTPS://seed.example/", ""},
+		{"udp://seed.example:6969/", "schéma"},
+		{"ftp://seed.example/release/", "schéma"},
+		{"seed.example/release/", "schéma"},
+		{"https:///release/", "hôte manquant"},
+		{"http://seed.example:port/", "invalide"},
+	}
+
+	for _, tt := range tests {
+		err := ValidateWebSeedURL(tt.url)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("ValidateWebSeedURL(%q) = %v", tt.url, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("ValidateWebSeedURL(%q) = %v, attendu %q", tt.url, err, tt.wantErr)
+		}
+	}
+}
+
+func TestProfileWebSeedsMerge(t *testing.T) {
+	dir := t.TempDir()
+	release := filepath.Join(dir, "Serie.S01")
+	writeRandomFile(t, filepath.Join(release, "e01.mkv"), 40000, 1)
+	writeRandomFile(t, filepath.Join(release, "e02.mkv"), 30000, 2)
+
+	// Web seeds du run (CLI / configuration) puis modèles de chaque profil, évalués avec son nom
+	data := WebSeedData{Name: "Serie.S01"}
+	runSeeds, err := ExpandWebSeeds([]string{"https://seed.example/{{.Name}}/"}, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewGenerator()
+	if err := g.SetWebSeeds(runSeeds); err != nil {
+		t.Fatal(err)
+	}
+
+	var profiles []Profile
+	for _, name := range []string{"a", "b"} {
+		profileData := data
+		profileData.Profile = name
+		seeds, err := ExpandWebSeeds([]string{"https://{{.Profile}}.example/{{.Name}}/", "https://seed.example/{{.Name}}/"}, profileData)
+		if err != nil {
+			t.Fatal(err)
+		}
+		profiles = append(profiles, Profile{Name: name, WebSeeds: seeds})
+	}
+	profiles = append(profiles, Profile{Name: "sans"})
+
+	outputs := []string{filepath.Join(dir, "a.torrent"), filepath.Join(dir, "b.torrent"), filepath.Join(dir, "sans.torrent")}
+	if err := g.CreateForProfiles(context.Background(), release, profiles, outputs); err != nil {
+		t.Fatal(err)
+	}
+
+	// Les web seeds du profil s'ajoutent à ceux du run, sans doublon, et ne fuient pas vers les autres profils
+	want := [][]string{
+		{"https://seed.example/Serie.S01/", "https://a.example/Serie.S01/"},
+		{"https://seed.example/Serie.S01/", "https://b.example/Serie.S01/"},
+		{"https://seed.example/Serie.S01/"},
+	}
+	for i, output := range outputs {
+		mi, err := metainfo.LoadFromFile(output)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := []string(mi.UrlList); !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("%s: web seeds = %q, attendu %q", profiles[i].Name, got, want[i])
+		}
+	}
+
+	// Un web seed invalide dans un profil est refusé
+	bad := Profile{Name: "bad", WebSeeds: []string{"ftp://seed.example/"}}
+	if err := g.ApplyProfile(bad); err == nil {
+		t.Error("un web seed ftp:// doit être refusé")
+	}
+
+	// Il est refusé avant l'écriture du torrent des autres profils
+	badOutputs := []string{filepath.Join(dir, "ok.torrent"), filepath.Join(dir, "bad.torrent")}
+	if err := NewGenerator().CreateForProfiles(context.Background(), release, []Profile{{Name: "ok"}, bad}, badOutputs); err == nil {
+		t.Error("CreateForProfiles() doit refuser le profil bad")
+	}
+	if _, err := os.Stat(badOutputs[0]); err == nil {
+		t.Error("aucun torrent ne devrait être écrit si un profil est refusé")
+	}
+}
@@ -54,32 +54,74 @@ func (p *InteractivePrompter) SelectMovie(movies []tmdb.Movie) (*tmdb.Movie, err
 	fmt.Println("  [0] Nouvelle recherche / Entrer un ID TMDB")
 	fmt.Println()
 
-	// Demander le choix
+	choice, err := p.readChoice(len(movies))
+	if err != nil {
+		return nil, err
+	}
+	return &movies[choice], nil
+}
+
+// SelectShow affiche une liste de séries et retourne le choix de l'utilisateur
+func (p *InteractivePrompter) SelectShow(shows []tmdb.Show) (*tmdb.Show, error) {
+	if len(shows) == 0 {
+		return nil, fmt.Errorf("aucune série à sélectionner")
+	}
+
+	fmt.Println("\n📺 Résultats de recherche:")
+	fmt.Println(strings.Repeat("─", 60))
+
+	for i, show := range shows {
+		rating := ""
+		if show.VoteAverage > 0 {
+			rating = fmt.Sprintf(" ⭐ %.1f", show.VoteAverage)
+		}
+
+		fmt.Printf("  [%d] %s (%s)%s\n", i+1, show.Name, show.Year(), rating)
+
+		if show.OriginalName != "" && show.OriginalName != show.Name {
+			fmt.Printf("      └─ %s\n", show.OriginalName)
+		}
+	}
+
+	fmt.Println(strings.Repeat("─", 60))
+	fmt.Println("  [0] Nouvelle recherche / Entrer un ID TMDB")
+	fmt.Println()
+
+	choice, err := p.readChoice(len(shows))
+	if err != nil {
+		return nil, err
+	}
+	return &shows[choice], nil
+}
+
+// readChoice lit le numéro d'un résultat (1 à count) et retourne son index.
+// 0 retourne une erreur pour déclencher une nouvelle recherche.
+func (p *InteractivePrompter) readChoice(count int) (int, error) {
 	for {
 		fmt.Print("Votre choix: ")
 		input, err := p.reader.ReadString('\n')
 		if err != nil {
 			if err.Error() == "EOF" {
-				return nil, fmt.Errorf("impossible de lire l'entrée (pas de TTY). Pour Docker, utilisez: docker run -it")
+				return 0, fmt.Errorf("impossible de lire l'entrée (pas de TTY). Pour Docker, utilisez: docker run -it")
 			}
-			return nil, err
+			return 0, err
 		}
 
 		input = strings.TrimSpace(input)
 
 		// Si c'est 0, on retourne une erreur pour déclencher une nouvelle recherche
 		if input == "0" {
-			return nil, fmt.Errorf("nouvelle recherche demandée")
+			return 0, fmt.Errorf("nouvelle recherche demandée")
 		}
 
 		// Essayer de parser comme un numéro
 		choice, err := strconv.Atoi(input)
-		if err != nil || choice < 1 || choice > len(movies) {
-			fmt.Printf("❌ Choix invalide. Entrez un nombre entre 1 et %d\n", len(movies))
+		if err != nil || choice < 1 || choice > count {
+			fmt.Printf("❌ Choix invalide. Entrez un nombre entre 1 et %d\n", count)
 			continue
 		}
 
-		return &movies[choice-1], nil
+		return choice - 1, nil
 	}
 }
 
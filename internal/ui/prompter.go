@@ -11,6 +11,9 @@ type Prompter interface {
 	// SelectMovie affiche une liste de films et retourne le choix de l'utilisateur
 	SelectMovie(movies []tmdb.Movie) (*tmdb.Movie, error)
 
+	// SelectShow affiche une liste de séries et retourne le choix de l'utilisateur
+	SelectShow(shows []tmdb.Show) (*tmdb.Show, error)
+
 	// SelectSourceType demande à l'utilisateur de choisir le type de source
 	SelectSourceType() (string, error)
 
@@ -20,7 +20,7 @@ func NewSilentPrompter() *SilentPrompter {
 	}
 }
 
-// SetDefaultMovieIndex définit l'index par défaut pour la sélection de films
+// SetDefaultMovieIndex définit l'index par défaut pour la sélection de films et de séries
 func (p *SilentPrompter) SetDefaultMovieIndex(index int) {
 	p.defaultMovieIndex = index
 }
@@ -49,6 +49,20 @@ func (p *SilentPrompter) SelectMovie(movies []tmdb.Movie) (*tmdb.Movie, error) {
 	return &movies[index], nil
 }
 
+// SelectShow retourne automatiquement la première série (ou l'index configuré)
+func (p *SilentPrompter) SelectShow(shows []tmdb.Show) (*tmdb.Show, error) {
+	if len(shows) == 0 {
+		return nil, nil
+	}
+
+	index := p.defaultMovieIndex
+	if index >= len(shows) {
+		index = 0
+	}
+
+	return &shows[index], nil
+}
+
 // SelectSourceType retourne le type de source par défaut
 func (p *SilentPrompter) SelectSourceType() (string, error) {
 	if p.defaultSourceType == "" {
@@ -0,0 +1,332 @@
+package uploader
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Catégories de release reconnues
+const (
+	CategoryMovie = "movie"
+	CategoryTV    = "tv"
+)
+
+// Config décrit l'API d'upload d'un tracker UNIT3D (section upload d'un profil de tracker).
+// Les identifiants de catégorie, type et résolution dépendent de chaque tracker:
+// les valeurs configurées complètent ou remplacent celles d'une installation UNIT3D standard.
+type Config struct {
+	URL         string         `mapstructure:"url"`
+	APIKey      string         `mapstructure:"api_key"`
+	Anonymous   bool           `mapstructure:"anonymous"`
+	Categories  map[string]int `mapstructure:"categories"`
+	Types       map[string]int `mapstructure:"types"`
+	Resolutions map[string]int `mapstructure:"resolutions"`
+	Timeout     time.Duration  `mapstructure:"timeout"`
+}
+
+// Identifiants par défaut d'une installation UNIT3D
+var (
+	defaultCategories  = map[string]int{CategoryMovie: 1, CategoryTV: 2}
+	defaultTypes       = map[string]int{"full-disc": 1, "remux": 2, "encode": 3, "web-dl": 4, "webrip": 5, "hdtv": 6}
+	defaultResolutions = map[string]int{
+		"4320p": 1, "2160p": 2, "1080p": 3, "1080i": 4, "720p": 5,
+		"576p": 6, "576i": 7, "480p": 8, "480i": 9, "other": 10,
+	}
+)
+
+// Release contient les informations d'une release à publier
+type Release struct {
+	TorrentPath string
+	Name        string // nom de release (renamer.GenerateName)
+	Description string // présentation BBCode
+	MediaInfo   string // sortie texte brute de mediainfo
+	Category    string // movie ou tv
+	SourceType  string // type de source choisi (BluRay, REMUX, WEB...)
+	Resolution  string // résolution détectée (1080p, 2160p...)
+	TMDbID      int
+	IMDbID      string // tt0133093 ou 0133093
+	Season      int    // séries: numéro de saison
+	Episode     int    // séries: numéro d'épisode (0 pour une saison complète)
+}
+
+// Result contient la réponse du tracker à un upload
+type Result struct {
+	Message     string
+	DownloadURL string
+	Fields      map[string]string // champs envoyés (ou qui l'auraient été en dry-run)
+	DryRun      bool
+}
+
+// APIError décrit un refus du tracker, avec les erreurs de validation par champ
+type APIError struct {
+	StatusCode int
+	Message    string
+	Fields     map[string][]string
+}
+
+// Error implémente l'interface error
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("upload refusé (HTTP %d)", e.StatusCode)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+
+	names := make([]string, 0, len(e.Fields))
+	for name := range e.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		msg += fmt.Sprintf("\n  - %s: %s", name, strings.Join(e.Fields[name], ", "))
+	}
+	return msg
+}
+
+// UNIT3D publie des releases via l'API /api/torrents/upload d'un tracker UNIT3D
+type UNIT3D struct {
+	config     Config
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewUNIT3D crée un uploader pour un tracker UNIT3D
+func NewUNIT3D(cfg Config) (*UNIT3D, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("URL du tracker manquante")
+	}
+	if cfg.APIKey == "" {
+		return nil, fmt.Errorf("clé d'API manquante")
+	}
+
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = 60 * time.Second
+	}
+
+	return &UNIT3D{
+		config:     cfg,
+		baseURL:    strings.TrimRight(cfg.URL, "/"),
+		httpClient: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// TypeKey associe un type de source du prompteur au type UNIT3D correspondant
+func TypeKey(sourceType string) string {
+	switch strings.ToLower(sourceType) {
+	case "remux":
+		return "remux"
+	case "web", "web-dl", "webdl":
+		return "web-dl"
+	case "webrip":
+		return "webrip"
+	case "hdtv":
+		return "hdtv"
+	case "full-disc", "bdmv", "iso":
+		return "full-disc"
+	default:
+		// BluRay, BluRay.HDLight, BluRay.4KLight: réencodages
+		return "encode"
+	}
+}
+
+// Fields construit les champs du formulaire d'upload (hors fichier torrent)
+func (u *UNIT3D) Fields(r Release) (map[string]string, error) {
+	category, err := lookupID("catégorie", r.Category, defaultCategories, u.config.Categories)
+	if err != nil {
+		return nil, err
+	}
+	typeID, err := lookupID("type", TypeKey(r.SourceType), defaultTypes, u.config.Types)
+	if err != nil {
+		return nil, err
+	}
+	resolution := strings.ToLower(r.Resolution)
+	if _, ok := mergedIDs(defaultResolutions, u.config.Resolutions)[resolution]; !ok {
+		resolution = "other"
+	}
+	resolutionID, err := lookupID("résolution", resolution, defaultResolutions, u.config.Resolutions)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Name == "" {
+		return nil, fmt.Errorf("nom de release manquant")
+	}
+
+	// UNIT3D attend l'identifiant IMDb sous forme numérique, sans le préfixe tt
+	imdb := strings.TrimLeft(strings.TrimPrefix(strings.ToLower(r.IMDbID), "tt"), "0")
+	if imdb == "" {
+		imdb = "0"
+	}
+	if _, err := strconv.Atoi(imdb); err != nil {
+		return nil, fmt.Errorf("identifiant IMDb invalide: %q", r.IMDbID)
+	}
+
+	fields := map[string]string{
+		"name":             r.Name,
+		"description":      r.Description,
+		"mediainfo":        r.MediaInfo,
+		"category_id":      strconv.Itoa(category),
+		"type_id":          strconv.Itoa(typeID),
+		"resolution_id":    strconv.Itoa(resolutionID),
+		"tmdb":             strconv.Itoa(r.TMDbID),
+		"imdb":             imdb,
+		"tvdb":             "0",
+		"mal":              "0",
+		"igdb":             "0",
+		"anonymous":        boolField(u.config.Anonymous),
+		"stream":           "0",
+		"sd":               boolField(isSD(resolution)),
+		"internal":         "0",
+		"personal_release": "0",
+	}
+	// UNIT3D demande la saison et l'épisode pour la catégorie séries
+	if r.Category == CategoryTV {
+		fields["season_number"] = strconv.Itoa(r.Season)
+		fields["episode_number"] = strconv.Itoa(r.Episode)
+	}
+	return fields, nil
+}
+
+// Upload publie la release. En dry-run, les champs sont validés et retournés sans rien envoyer.
+func (u *UNIT3D) Upload(ctx context.Context, r Release, dryRun bool) (*Result, error) {
+	fields, err := u.Fields(r)
+	if err != nil {
+		return nil, err
+	}
+
+	torrentData, err := os.ReadFile(r.TorrentPath)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lecture torrent: %w", err)
+	}
+
+	if dryRun {
+		return &Result{Message: "dry-run: aucun envoi", Fields: fields, DryRun: true}, nil
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("torrent", filepath.Base(r.TorrentPath))
+	if err != nil {
+		return nil, fmt.Errorf("erreur construction requête: %w", err)
+	}
+	if _, err := part.Write(torrentData); err != nil {
+		return nil, fmt.Errorf("erreur construction requête: %w", err)
+	}
+	for key, value := range fields {
+		if err := form.WriteField(key, value); err != nil {
+			return nil, fmt.Errorf("erreur construction requête: %w", err)
+		}
+	}
+	if err := form.Close(); err != nil {
+		return nil, fmt.Errorf("erreur construction requête: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.baseURL+"/api/torrents/upload", &body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+u.config.APIKey)
+
+	resp, err := u.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("erreur requête upload: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lecture réponse upload: %w", err)
+	}
+
+	return parseResponse(resp.StatusCode, data, fields)
+}
+
+// unit3dResponse est l'enveloppe des réponses de l'API UNIT3D
+type unit3dResponse struct {
+	Success bool            `json:"success"`
+	Data    json.RawMessage `json:"data"`
+	Message string          `json:"message"`
+}
+
+// parseResponse interprète la réponse du tracker
+func parseResponse(status int, data []byte, fields map[string]string) (*Result, error) {
+	var resp unit3dResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		// Réponse non JSON (page HTML d'erreur, clé refusée...)
+		apiErr := &APIError{StatusCode: status, Message: strings.TrimSpace(string(data))}
+		if len(apiErr.Message) > 200 {
+			apiErr.Message = apiErr.Message[:200] + "..."
+		}
+		return nil, apiErr
+	}
+
+	if status < 200 || status >= 300 || !resp.Success {
+		apiErr := &APIError{StatusCode: status, Message: resp.Message}
+		// En cas d'erreur de validation, data contient les messages par champ
+		var fieldErrors map[string][]string
+		if json.Unmarshal(resp.Data, &fieldErrors) == nil {
+			apiErr.Fields = fieldErrors
+		} else {
+			var text string
+			if json.Unmarshal(resp.Data, &text) == nil && text != "" && text != resp.Message {
+				apiErr.Message = strings.TrimSpace(apiErr.Message + " " + text)
+			}
+		}
+		return nil, apiErr
+	}
+
+	result := &Result{Message: resp.Message, Fields: fields}
+	json.Unmarshal(resp.Data, &result.DownloadURL)
+	return result, nil
+}
+
+// lookupID retourne l'identifiant configuré pour une clé, sinon celui par défaut
+func lookupID(kind, key string, defaults, overrides map[string]int) (int, error) {
+	ids := mergedIDs(defaults, overrides)
+	id, ok := ids[strings.ToLower(key)]
+	if !ok {
+		return 0, fmt.Errorf("%s %q sans identifiant pour ce tracker", kind, key)
+	}
+	return id, nil
+}
+
+// mergedIDs fusionne les identifiants par défaut et ceux configurés (clés en minuscules)
+func mergedIDs(defaults, overrides map[string]int) map[string]int {
+	ids := make(map[string]int, len(defaults)+len(overrides))
+	for key, id := range defaults {
+		ids[key] = id
+	}
+	for key, id := range overrides {
+		ids[strings.ToLower(key)] = id
+	}
+	return ids
+}
+
+// isSD indique si une résolution est en définition standard
+func isSD(resolution string) bool {
+	switch resolution {
+	case "576p", "576i", "480p", "480i":
+		return true
+	}
+	return false
+}
+
+func boolField(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
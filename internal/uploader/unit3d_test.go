@@ -0,0 +1,187 @@
+package uploader
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testRelease(t *testing.T) Release {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "Movie.torrent")
+	if err := os.WriteFile(path, []byte("d4:infod4:name5:Movieee"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return Release{
+		TorrentPath: path,
+		Name:        "The.Matrix.1999.MULTI.1080p.BluRay.x264.DTS.5.1-GRP",
+		Description: "[b]The Matrix[/b]",
+		MediaInfo:   "General\nComplete name : The.Matrix.mkv",
+		Category:    CategoryMovie,
+		SourceType:  "BluRay",
+		Resolution:  "1080p",
+		TMDbID:      603,
+		IMDbID:      "tt0133093",
+	}
+}
+
+func TestUNIT3DUpload(t *testing.T) {
+	release := testRelease(t)
+
+	var fields map[string]string
+	var torrent []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/torrents/upload" || r.Method != http.MethodPost {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer KEY" {
+			w.WriteHeader(http.StatusUnauthorized)
+			io.WriteString(w, `{"message":"Unauthenticated."}`)
+			return
+		}
+		// t.Fatal ne doit pas être appelé hors de la goroutine du test
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Error(err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fields = map[string]string{}
+		for key, values := range r.MultipartForm.Value {
+			fields[key] = values[0]
+		}
+		file, _, err := r.FormFile("torrent")
+		if err != nil {
+			t.Error(err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		torrent, _ = io.ReadAll(file)
+
+		if fields["name"] == "Already.Uploaded-GRP" {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"success":false,"data":{"name":["The name has already been taken."],"info_hash":["The info hash has already been taken."]},"message":"Validation Error."}`)
+			return
+		}
+		io.WriteString(w, `{"success":true,"data":"https://tracker.example/torrent/download/42.abc","message":"Torrent uploaded successfully."}`)
+	}))
+	defer server.Close()
+
+	u, err := NewUNIT3D(Config{URL: server.URL + "/", APIKey: "KEY", Types: map[string]int{"Encode": 30}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := u.Upload(context.Background(), release, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.DownloadURL != "https://tracker.example/torrent/download/42.abc" {
+		t.Errorf("download URL = %q", result.DownloadURL)
+	}
+	want := map[string]string{
+		"name":          release.Name,
+		"description":   release.Description,
+		"mediainfo":     release.MediaInfo,
+		"category_id":   "1",
+		"type_id":       "30",
+		"resolution_id": "3",
+		"tmdb":          "603",
+		"imdb":          "133093",
+		"anonymous":     "0",
+		"sd":            "0",
+	}
+	for key, value := range want {
+		if fields[key] != value {
+			t.Errorf("%s = %q, want %q", key, fields[key], value)
+		}
+	}
+	if string(torrent) != "d4:infod4:name5:Movieee" {
+		t.Errorf("torrent envoyé = %q", torrent)
+	}
+
+	// Erreur de validation: chaque champ refusé est rapporté
+	release.Name = "Already.Uploaded-GRP"
+	_, err = u.Upload(context.Background(), release, false)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("error = %v, want *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || len(apiErr.Fields) != 2 {
+		t.Errorf("APIError = %+v", apiErr)
+	}
+	if !strings.Contains(err.Error(), "info_hash: The info hash has already been taken.") {
+		t.Errorf("message d'erreur peu explicite: %v", err)
+	}
+
+	// Clé d'API refusée
+	bad, _ := NewUNIT3D(Config{URL: server.URL, APIKey: "WRONG"})
+	if _, err := bad.Upload(context.Background(), release, false); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("error = %v, want 401", err)
+	}
+}
+
+func TestUNIT3DDryRun(t *testing.T) {
+	release := testRelease(t)
+	release.SourceType = "WEB"
+	release.Resolution = "2160p"
+
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	u, _ := NewUNIT3D(Config{URL: server.URL, APIKey: "KEY", Anonymous: true})
+	result, err := u.Upload(context.Background(), release, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if called {
+		t.Error("le dry-run ne doit rien envoyer")
+	}
+	if !result.DryRun || result.Fields["type_id"] != "4" || result.Fields["resolution_id"] != "2" || result.Fields["anonymous"] != "1" {
+		t.Errorf("result = %+v", result)
+	}
+
+	if _, ok := result.Fields["season_number"]; ok {
+		t.Error("season_number ne concerne que les séries")
+	}
+
+	release.Category = CategoryTV
+	release.Season = 2
+	result, err = u.Upload(context.Background(), release, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Fields["category_id"] != "2" || result.Fields["season_number"] != "2" || result.Fields["episode_number"] != "0" {
+		t.Errorf("champs série = %+v", result.Fields)
+	}
+
+	release.Category = "music"
+	if _, err := u.Upload(context.Background(), release, true); err == nil {
+		t.Error("une catégorie sans identifiant devrait être refusée")
+	}
+}
+
+func TestTypeKey(t *testing.T) {
+	tests := map[string]string{
+		"BluRay":         "encode",
+		"BluRay.HDLight": "encode",
+		"BluRay.4KLight": "encode",
+		"REMUX":          "remux",
+		"WEB":            "web-dl",
+		"WEBRip":         "webrip",
+	}
+	for source, want := range tests {
+		if got := TypeKey(source); got != want {
+			t.Errorf("TypeKey(%q) = %q, want %q", source, got, want)
+		}
+	}
+}